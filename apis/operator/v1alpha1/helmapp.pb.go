@@ -12,8 +12,9 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
-	_ "google.golang.org/protobuf/types/known/timestamppb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -87,6 +88,33 @@ type HelmAppSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	GlobalValues *structpb.Struct `protobuf:"bytes,2,opt,name=globalValues,proto3" json:"globalValues,omitempty"`
 	Repo         *HelmRepo        `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+	// Maximum number of components reconciled at the same time, the operator
+	// default is used when unset.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrency int32 `protobuf:"varint,4,opt,name=maxConcurrency,proto3" json:"maxConcurrency,omitempty"`
+	// Values read from ConfigMaps and Secrets, merged in order before
+	// globalValues.
+	ValuesFrom []*ValuesReference `protobuf:"bytes,5,rep,name=valuesFrom,proto3" json:"valuesFrom,omitempty"`
+	// Stop installing, upgrading and uninstalling the releases of every
+	// component until unset.
+	Suspend bool `protobuf:"varint,6,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	// Render the changes of the releases with a dry-run and only apply them
+	// once the hash of the plan is set in the helmapp.pluma.io/approve-plan
+	// annotation.
+	Plan bool `protobuf:"varint,7,opt,name=plan,proto3" json:"plan,omitempty"`
+	// What happens to the releases when the HelmApp is deleted or a component
+	// is removed: Delete uninstalls them (default), Orphan drops the releases
+	// and keeps their resources, KeepCRDs and KeepPVCs uninstall them but keep
+	// the CustomResourceDefinitions or PersistentVolumeClaims.
+	// +kubebuilder:validation:Enum=Delete;Orphan;KeepCRDs;KeepPVCs
+	DeletionPolicy string `protobuf:"bytes,8,opt,name=deletionPolicy,proto3" json:"deletionPolicy,omitempty"`
+	// Secret in the HelmApp namespace holding the kubeconfig of the cluster
+	// the releases are installed in, the cluster of the operator when unset.
+	KubeConfigSecretRef *SecretKeyReference `protobuf:"bytes,9,opt,name=kubeConfigSecretRef,proto3" json:"kubeConfigSecretRef,omitempty"`
+	// ServiceAccount of the HelmApp namespace the releases are installed as,
+	// the operator itself when unset. With kubeConfigSecretRef the
+	// ServiceAccount is impersonated in the remote cluster.
+	ServiceAccountName string `protobuf:"bytes,10,opt,name=serviceAccountName,proto3" json:"serviceAccountName,omitempty"`
 }
 
 func (x *HelmAppSpec) Reset() {
@@ -142,6 +170,55 @@ func (x *HelmAppSpec) GetRepo() *HelmRepo {
 	return nil
 }
 
+func (x *HelmAppSpec) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *HelmAppSpec) GetValuesFrom() []*ValuesReference {
+	if x != nil {
+		return x.ValuesFrom
+	}
+	return nil
+}
+
+func (x *HelmAppSpec) GetSuspend() bool {
+	if x != nil {
+		return x.Suspend
+	}
+	return false
+}
+
+func (x *HelmAppSpec) GetPlan() bool {
+	if x != nil {
+		return x.Plan
+	}
+	return false
+}
+
+func (x *HelmAppSpec) GetDeletionPolicy() string {
+	if x != nil {
+		return x.DeletionPolicy
+	}
+	return ""
+}
+
+func (x *HelmAppSpec) GetKubeConfigSecretRef() *SecretKeyReference {
+	if x != nil {
+		return x.KubeConfigSecretRef
+	}
+	return nil
+}
+
+func (x *HelmAppSpec) GetServiceAccountName() string {
+	if x != nil {
+		return x.ServiceAccountName
+	}
+	return ""
+}
+
 type HelmComponent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -154,6 +231,48 @@ type HelmComponent struct {
 	ComponentValues    *structpb.Struct `protobuf:"bytes,4,opt,name=componentValues,proto3" json:"componentValues,omitempty"`
 	Repo               *HelmRepo        `protobuf:"bytes,5,opt,name=repo,proto3" json:"repo,omitempty"`
 	IgnoreGlobalValues bool             `protobuf:"varint,6,opt,name=ignoreGlobalValues,proto3" json:"ignoreGlobalValues,omitempty"`
+	// Names of the components which must be deployed and healthy before this
+	// component is installed or upgraded, they are uninstalled after it.
+	DependsOn []string `protobuf:"bytes,7,rep,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+	// What to do when live objects drift from the release manifest: ignore
+	// them, report them in the status (default) or correct them by re-applying
+	// the manifest.
+	// +kubebuilder:validation:Enum=ignore;report;correct
+	DriftPolicy   string         `protobuf:"bytes,8,opt,name=driftPolicy,proto3" json:"driftPolicy,omitempty"`
+	UpgradePolicy *UpgradePolicy `protobuf:"bytes,9,opt,name=upgradePolicy,proto3" json:"upgradePolicy,omitempty"`
+	// Values read from ConfigMaps and Secrets, merged in order after the
+	// HelmApp values and before componentValues.
+	ValuesFrom []*ValuesReference `protobuf:"bytes,10,rep,name=valuesFrom,proto3" json:"valuesFrom,omitempty"`
+	// Namespace the release is installed in, defaults to the namespace of the
+	// HelmApp.
+	TargetNamespace string `protobuf:"bytes,11,opt,name=targetNamespace,proto3" json:"targetNamespace,omitempty"`
+	// Create the target namespace if it doesn't exist. Namespaces are never
+	// deleted by the operator.
+	CreateNamespace bool `protobuf:"varint,12,opt,name=createNamespace,proto3" json:"createNamespace,omitempty"`
+	// Labels and annotations set on the target namespace when createNamespace
+	// is set.
+	NamespaceMetadata *NamespaceMetadata `protobuf:"bytes,13,opt,name=namespaceMetadata,proto3" json:"namespaceMetadata,omitempty"`
+	// Stop installing, upgrading and uninstalling the release of the component
+	// until unset.
+	Suspend bool        `protobuf:"varint,14,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	Test    *TestPolicy `protobuf:"bytes,15,opt,name=test,proto3" json:"test,omitempty"`
+	// Overrides the deletionPolicy of the HelmApp for the component.
+	// +kubebuilder:validation:Enum=Delete;Orphan;KeepCRDs;KeepPVCs
+	DeletionPolicy string `protobuf:"bytes,16,opt,name=deletionPolicy,proto3" json:"deletionPolicy,omitempty"`
+	// How the CRDs of the crds/ directories of the chart are managed. Skip
+	// leaves them out, Create creates the missing ones and CreateReplace also
+	// updates the existing ones with server-side apply, both before installing
+	// or upgrading and waiting for them to be established. When unset helm
+	// creates them on install and never updates them.
+	// +kubebuilder:validation:Enum=Skip;Create;CreateReplace
+	Crds string `protobuf:"bytes,17,opt,name=crds,proto3" json:"crds,omitempty"`
+	// Patches applied in order to the rendered manifests on install and
+	// upgrade.
+	Patches []*PostRenderPatch `protobuf:"bytes,18,rep,name=patches,proto3" json:"patches,omitempty"`
+	// Render the string values of globalValues and componentValues as Go
+	// templates of the HelmApp metadata and the cluster variables. Values are
+	// used as is when unset, so they can hold templates of the chart.
+	TemplateValues bool `protobuf:"varint,19,opt,name=templateValues,proto3" json:"templateValues,omitempty"`
 }
 
 func (x *HelmComponent) Reset() {
@@ -230,17 +349,115 @@ func (x *HelmComponent) GetIgnoreGlobalValues() bool {
 	return false
 }
 
-type HelmRepo struct {
+func (x *HelmComponent) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *HelmComponent) GetDriftPolicy() string {
+	if x != nil {
+		return x.DriftPolicy
+	}
+	return ""
+}
+
+func (x *HelmComponent) GetUpgradePolicy() *UpgradePolicy {
+	if x != nil {
+		return x.UpgradePolicy
+	}
+	return nil
+}
+
+func (x *HelmComponent) GetValuesFrom() []*ValuesReference {
+	if x != nil {
+		return x.ValuesFrom
+	}
+	return nil
+}
+
+func (x *HelmComponent) GetTargetNamespace() string {
+	if x != nil {
+		return x.TargetNamespace
+	}
+	return ""
+}
+
+func (x *HelmComponent) GetCreateNamespace() bool {
+	if x != nil {
+		return x.CreateNamespace
+	}
+	return false
+}
+
+func (x *HelmComponent) GetNamespaceMetadata() *NamespaceMetadata {
+	if x != nil {
+		return x.NamespaceMetadata
+	}
+	return nil
+}
+
+func (x *HelmComponent) GetSuspend() bool {
+	if x != nil {
+		return x.Suspend
+	}
+	return false
+}
+
+func (x *HelmComponent) GetTest() *TestPolicy {
+	if x != nil {
+		return x.Test
+	}
+	return nil
+}
+
+func (x *HelmComponent) GetDeletionPolicy() string {
+	if x != nil {
+		return x.DeletionPolicy
+	}
+	return ""
+}
+
+func (x *HelmComponent) GetCrds() string {
+	if x != nil {
+		return x.Crds
+	}
+	return ""
+}
+
+func (x *HelmComponent) GetPatches() []*PostRenderPatch {
+	if x != nil {
+		return x.Patches
+	}
+	return nil
+}
+
+func (x *HelmComponent) GetTemplateValues() bool {
+	if x != nil {
+		return x.TemplateValues
+	}
+	return false
+}
+
+// A patch of the rendered manifests of a component.
+type PostRenderPatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Url  string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Resources the patch applies to, every resource when unset.
+	Target *PatchTarget `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// Kind of patch, defaults to StrategicMerge. Resources without a known
+	// schema get a JSON merge patch instead of a strategic merge patch.
+	// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// YAML of the strategic merge patch or of the list of JSON6902 operations.
+	Patch string `protobuf:"bytes,3,opt,name=patch,proto3" json:"patch,omitempty"`
 }
 
-func (x *HelmRepo) Reset() {
-	*x = HelmRepo{}
+func (x *PostRenderPatch) Reset() {
+	*x = PostRenderPatch{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -248,13 +465,13 @@ func (x *HelmRepo) Reset() {
 	}
 }
 
-func (x *HelmRepo) String() string {
+func (x *PostRenderPatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HelmRepo) ProtoMessage() {}
+func (*PostRenderPatch) ProtoMessage() {}
 
-func (x *HelmRepo) ProtoReflect() protoreflect.Message {
+func (x *PostRenderPatch) ProtoReflect() protoreflect.Message {
 	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -266,38 +483,47 @@ func (x *HelmRepo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HelmRepo.ProtoReflect.Descriptor instead.
-func (*HelmRepo) Descriptor() ([]byte, []int) {
+// Deprecated: Use PostRenderPatch.ProtoReflect.Descriptor instead.
+func (*PostRenderPatch) Descriptor() ([]byte, []int) {
 	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *HelmRepo) GetName() string {
+func (x *PostRenderPatch) GetTarget() *PatchTarget {
 	if x != nil {
-		return x.Name
+		return x.Target
+	}
+	return nil
+}
+
+func (x *PostRenderPatch) GetType() string {
+	if x != nil {
+		return x.Type
 	}
 	return ""
 }
 
-func (x *HelmRepo) GetUrl() string {
+func (x *PostRenderPatch) GetPatch() string {
 	if x != nil {
-		return x.Url
+		return x.Patch
 	}
 	return ""
 }
 
-type HelmAppStatus struct {
+// Selects the resources a patch applies to, all set fields must match.
+type PatchTarget struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// +kubebuilder:validation:Enum=UNKNOWN;RECONCILING;SUCCEEDED;FAILED;DELETING
-	// +kubebuilder:validation:Format:type=string
-	Phase      Phase                  `protobuf:"varint,1,opt,name=phase,proto3,enum=pluma.operator.v1alpha1.Phase" json:"phase,omitempty"`
-	Components []*HelmComponentStatus `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	Kind      string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Label selector of the resources, e.g. app=istiod,istio.io/rev!=canary.
+	LabelSelector string `protobuf:"bytes,4,opt,name=labelSelector,proto3" json:"labelSelector,omitempty"`
 }
 
-func (x *HelmAppStatus) Reset() {
-	*x = HelmAppStatus{}
+func (x *PatchTarget) Reset() {
+	*x = PatchTarget{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -305,13 +531,13 @@ func (x *HelmAppStatus) Reset() {
 	}
 }
 
-func (x *HelmAppStatus) String() string {
+func (x *PatchTarget) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HelmAppStatus) ProtoMessage() {}
+func (*PatchTarget) ProtoMessage() {}
 
-func (x *HelmAppStatus) ProtoReflect() protoreflect.Message {
+func (x *PatchTarget) ProtoReflect() protoreflect.Message {
 	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -323,40 +549,56 @@ func (x *HelmAppStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HelmAppStatus.ProtoReflect.Descriptor instead.
-func (*HelmAppStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use PatchTarget.ProtoReflect.Descriptor instead.
+func (*PatchTarget) Descriptor() ([]byte, []int) {
 	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *HelmAppStatus) GetPhase() Phase {
+func (x *PatchTarget) GetKind() string {
 	if x != nil {
-		return x.Phase
+		return x.Kind
 	}
-	return Phase_UNKNOWN
+	return ""
 }
 
-func (x *HelmAppStatus) GetComponents() []*HelmComponentStatus {
+func (x *PatchTarget) GetName() string {
 	if x != nil {
-		return x.Components
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type HelmComponentStatus struct {
+func (x *PatchTarget) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PatchTarget) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+// Policy of the helm tests run after the release of a component is installed
+// or upgraded.
+type TestPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name           string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Status         string                `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Message        string                `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Version        string                `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	Resources      []*HelmResourceStatus `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty"`
-	ResourcesTotal int32                 `protobuf:"varint,6,opt,name=resourcesTotal,proto3" json:"resourcesTotal,omitempty"`
+	// Run the test hooks of the chart.
+	Enable bool `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	// Time to wait for the tests, defaults to 5m.
+	Timeout *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Roll an upgrade back to the last deployed revision when a test fails.
+	RollbackOnFailure bool `protobuf:"varint,3,opt,name=rollbackOnFailure,proto3" json:"rollbackOnFailure,omitempty"`
 }
 
-func (x *HelmComponentStatus) Reset() {
-	*x = HelmComponentStatus{}
+func (x *TestPolicy) Reset() {
+	*x = TestPolicy{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -364,13 +606,13 @@ func (x *HelmComponentStatus) Reset() {
 	}
 }
 
-func (x *HelmComponentStatus) String() string {
+func (x *TestPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HelmComponentStatus) ProtoMessage() {}
+func (*TestPolicy) ProtoMessage() {}
 
-func (x *HelmComponentStatus) ProtoReflect() protoreflect.Message {
+func (x *TestPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -382,81 +624,122 @@ func (x *HelmComponentStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HelmComponentStatus.ProtoReflect.Descriptor instead.
-func (*HelmComponentStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use TestPolicy.ProtoReflect.Descriptor instead.
+func (*TestPolicy) Descriptor() ([]byte, []int) {
 	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *HelmComponentStatus) GetName() string {
+func (x *TestPolicy) GetEnable() bool {
 	if x != nil {
-		return x.Name
+		return x.Enable
 	}
-	return ""
+	return false
 }
 
-func (x *HelmComponentStatus) GetStatus() string {
+func (x *TestPolicy) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Status
+		return x.Timeout
 	}
-	return ""
+	return nil
 }
 
-func (x *HelmComponentStatus) GetMessage() string {
+func (x *TestPolicy) GetRollbackOnFailure() bool {
 	if x != nil {
-		return x.Message
+		return x.RollbackOnFailure
 	}
-	return ""
+	return false
 }
 
-func (x *HelmComponentStatus) GetVersion() string {
-	if x != nil {
-		return x.Version
+type NamespaceMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels      map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *NamespaceMetadata) Reset() {
+	*x = NamespaceMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *HelmComponentStatus) GetResources() []*HelmResourceStatus {
+func (x *NamespaceMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceMetadata) ProtoMessage() {}
+
+func (x *NamespaceMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceMetadata.ProtoReflect.Descriptor instead.
+func (*NamespaceMetadata) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NamespaceMetadata) GetLabels() map[string]string {
 	if x != nil {
-		return x.Resources
+		return x.Labels
 	}
 	return nil
 }
 
-func (x *HelmComponentStatus) GetResourcesTotal() int32 {
+func (x *NamespaceMetadata) GetAnnotations() map[string]string {
 	if x != nil {
-		return x.ResourcesTotal
+		return x.Annotations
 	}
-	return 0
+	return nil
 }
 
-type HelmResourceStatus struct {
+// Values read from a ConfigMap or Secret in the namespace of the HelmApp.
+type ValuesReference struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
-	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace  string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Key holding the values, defaults to values.yaml.
+	ValuesKey string `protobuf:"bytes,3,opt,name=valuesKey,proto3" json:"valuesKey,omitempty"`
+	// Path to set the value of the key at, e.g. pilot.env.TOKEN. The whole
+	// key is merged as YAML values when empty.
+	TargetPath string `protobuf:"bytes,4,opt,name=targetPath,proto3" json:"targetPath,omitempty"`
+	// Skip the reference when the object or the key doesn't exist.
+	Optional bool `protobuf:"varint,5,opt,name=optional,proto3" json:"optional,omitempty"`
 }
 
-func (x *HelmResourceStatus) Reset() {
-	*x = HelmResourceStatus{}
+func (x *ValuesReference) Reset() {
+	*x = ValuesReference{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[5]
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HelmResourceStatus) String() string {
+func (x *ValuesReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HelmResourceStatus) ProtoMessage() {}
+func (*ValuesReference) ProtoMessage() {}
 
-func (x *HelmResourceStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[5]
+func (x *ValuesReference) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,53 +750,1355 @@ func (x *HelmResourceStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HelmResourceStatus.ProtoReflect.Descriptor instead.
-func (*HelmResourceStatus) Descriptor() ([]byte, []int) {
-	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ValuesReference.ProtoReflect.Descriptor instead.
+func (*ValuesReference) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *HelmResourceStatus) GetApiVersion() string {
+func (x *ValuesReference) GetKind() string {
 	if x != nil {
-		return x.ApiVersion
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *HelmResourceStatus) GetKind() string {
+func (x *ValuesReference) GetName() string {
 	if x != nil {
-		return x.Kind
+		return x.Name
 	}
 	return ""
 }
 
-func (x *HelmResourceStatus) GetName() string {
+func (x *ValuesReference) GetValuesKey() string {
 	if x != nil {
-		return x.Name
+		return x.ValuesKey
 	}
 	return ""
 }
 
-func (x *HelmResourceStatus) GetNamespace() string {
+func (x *ValuesReference) GetTargetPath() string {
 	if x != nil {
-		return x.Namespace
+		return x.TargetPath
 	}
 	return ""
 }
 
-var File_operator_v1alpha1_helmapp_proto protoreflect.FileDescriptor
+func (x *ValuesReference) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
 
-var file_operator_v1alpha1_helmapp_proto_rawDesc = []byte{
-	0x0a, 0x1f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2f, 0x68, 0x65, 0x6c, 0x6d, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x17, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
-	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x01, 0x0a, 0x0b, 0x48, 0x65,
-	0x6c, 0x6d, 0x41, 0x70, 0x70, 0x53, 0x70, 0x65, 0x63, 0x12, 0x46, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
-	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
-	0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+// Policy applied when the release of a component is installed or upgraded.
+type UpgradePolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Wait for the resources to be ready and undo a failed install or upgrade.
+	Atomic bool `protobuf:"varint,1,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	// Roll back to the last deployed revision when an upgrade fails.
+	RollbackOnFailure bool `protobuf:"varint,2,opt,name=rollbackOnFailure,proto3" json:"rollbackOnFailure,omitempty"`
+	// Delete the resources created by a failed upgrade.
+	CleanupOnFail bool `protobuf:"varint,3,opt,name=cleanupOnFail,proto3" json:"cleanupOnFail,omitempty"`
+	// Failed attempts of the same configuration before giving up, 0 retries
+	// forever.
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries int32 `protobuf:"varint,4,opt,name=maxRetries,proto3" json:"maxRetries,omitempty"`
+	// Time to wait for the resources when atomic or rolling back, defaults to
+	// 5m.
+	Timeout *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *UpgradePolicy) Reset() {
+	*x = UpgradePolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradePolicy) ProtoMessage() {}
+
+func (x *UpgradePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradePolicy.ProtoReflect.Descriptor instead.
+func (*UpgradePolicy) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpgradePolicy) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *UpgradePolicy) GetRollbackOnFailure() bool {
+	if x != nil {
+		return x.RollbackOnFailure
+	}
+	return false
+}
+
+func (x *UpgradePolicy) GetCleanupOnFail() bool {
+	if x != nil {
+		return x.CleanupOnFail
+	}
+	return false
+}
+
+func (x *UpgradePolicy) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *UpgradePolicy) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type HelmRepo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url  string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Secret in the HelmApp namespace holding the repo credentials, the keys
+	// username, password, token (OCI registries only), tls.crt, tls.key and
+	// ca.crt are read from it.
+	SecretRef *LocalObjectReference `protobuf:"bytes,3,opt,name=secretRef,proto3" json:"secretRef,omitempty"`
+	// Secret in the HelmApp namespace holding the CA bundle of the repo under
+	// the ca.crt key.
+	CaSecretRef           *LocalObjectReference `protobuf:"bytes,4,opt,name=caSecretRef,proto3" json:"caSecretRef,omitempty"`
+	InsecureSkipTLSVerify bool                  `protobuf:"varint,5,opt,name=insecureSkipTLSVerify,proto3" json:"insecureSkipTLSVerify,omitempty"`
+	// Pass the credentials to all domains, not only the domain of the repo.
+	PassCredentialsAll bool `protobuf:"varint,6,opt,name=passCredentialsAll,proto3" json:"passCredentialsAll,omitempty"`
+}
+
+func (x *HelmRepo) Reset() {
+	*x = HelmRepo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmRepo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmRepo) ProtoMessage() {}
+
+func (x *HelmRepo) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmRepo.ProtoReflect.Descriptor instead.
+func (*HelmRepo) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HelmRepo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmRepo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *HelmRepo) GetSecretRef() *LocalObjectReference {
+	if x != nil {
+		return x.SecretRef
+	}
+	return nil
+}
+
+func (x *HelmRepo) GetCaSecretRef() *LocalObjectReference {
+	if x != nil {
+		return x.CaSecretRef
+	}
+	return nil
+}
+
+func (x *HelmRepo) GetInsecureSkipTLSVerify() bool {
+	if x != nil {
+		return x.InsecureSkipTLSVerify
+	}
+	return false
+}
+
+func (x *HelmRepo) GetPassCredentialsAll() bool {
+	if x != nil {
+		return x.PassCredentialsAll
+	}
+	return false
+}
+
+type LocalObjectReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *LocalObjectReference) Reset() {
+	*x = LocalObjectReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocalObjectReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalObjectReference) ProtoMessage() {}
+
+func (x *LocalObjectReference) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalObjectReference.ProtoReflect.Descriptor instead.
+func (*LocalObjectReference) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LocalObjectReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SecretKeyReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Key of the Secret holding the value, defaults to value.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *SecretKeyReference) Reset() {
+	*x = SecretKeyReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretKeyReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretKeyReference) ProtoMessage() {}
+
+func (x *SecretKeyReference) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretKeyReference.ProtoReflect.Descriptor instead.
+func (*SecretKeyReference) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecretKeyReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretKeyReference) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type HelmAppStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// +kubebuilder:validation:Enum=UNKNOWN;RECONCILING;SUCCEEDED;FAILED;DELETING
+	// +kubebuilder:validation:Format:type=string
+	Phase      Phase                  `protobuf:"varint,1,opt,name=phase,proto3,enum=pluma.operator.v1alpha1.Phase" json:"phase,omitempty"`
+	Components []*HelmComponentStatus `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	// Ready, Reconciling, Stalled, Degraded and DependenciesReady conditions
+	// aggregated from the components.
+	Conditions []*Condition `protobuf:"bytes,3,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// Generation of the HelmApp the status was computed from.
+	// +kubebuilder:validation:XIntOrString
+	ObservedGeneration int64 `protobuf:"varint,4,opt,name=observedGeneration,proto3" json:"observedGeneration,omitempty"`
+	// Time of the last reconciliation.
+	LastAttemptedTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=lastAttemptedTime,proto3" json:"lastAttemptedTime,omitempty"`
+	// Time of the last reconciliation which left every component ready.
+	LastSuccessfulTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=lastSuccessfulTime,proto3" json:"lastSuccessfulTime,omitempty"`
+	// Changes waiting for approval in plan mode.
+	Plan *HelmAppPlanStatus `protobuf:"bytes,7,opt,name=plan,proto3" json:"plan,omitempty"`
+}
+
+func (x *HelmAppStatus) Reset() {
+	*x = HelmAppStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmAppStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmAppStatus) ProtoMessage() {}
+
+func (x *HelmAppStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmAppStatus.ProtoReflect.Descriptor instead.
+func (*HelmAppStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HelmAppStatus) GetPhase() Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return Phase_UNKNOWN
+}
+
+func (x *HelmAppStatus) GetComponents() []*HelmComponentStatus {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *HelmAppStatus) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *HelmAppStatus) GetObservedGeneration() int64 {
+	if x != nil {
+		return x.ObservedGeneration
+	}
+	return 0
+}
+
+func (x *HelmAppStatus) GetLastAttemptedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAttemptedTime
+	}
+	return nil
+}
+
+func (x *HelmAppStatus) GetLastSuccessfulTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccessfulTime
+	}
+	return nil
+}
+
+func (x *HelmAppStatus) GetPlan() *HelmAppPlanStatus {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+type HelmAppPlanStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Hash to set in the helmapp.pluma.io/approve-plan annotation to apply the
+	// plans of the components.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// ConfigMap in the HelmApp namespace holding the diffs under the
+	// <component>.diff keys, set when the diffs are too large for the status.
+	DiffConfigMap string `protobuf:"bytes,2,opt,name=diffConfigMap,proto3" json:"diffConfigMap,omitempty"`
+}
+
+func (x *HelmAppPlanStatus) Reset() {
+	*x = HelmAppPlanStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmAppPlanStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmAppPlanStatus) ProtoMessage() {}
+
+func (x *HelmAppPlanStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmAppPlanStatus.ProtoReflect.Descriptor instead.
+func (*HelmAppPlanStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HelmAppPlanStatus) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *HelmAppPlanStatus) GetDiffConfigMap() string {
+	if x != nil {
+		return x.DiffConfigMap
+	}
+	return ""
+}
+
+type HelmComponentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status         string                `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message        string                `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Version        string                `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Resources      []*HelmResourceStatus `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty"`
+	ResourcesTotal int32                 `protobuf:"varint,6,opt,name=resourcesTotal,proto3" json:"resourcesTotal,omitempty"`
+	// Number of resources which drifted from the release manifest.
+	DriftedResources int32 `protobuf:"varint,7,opt,name=driftedResources,proto3" json:"driftedResources,omitempty"`
+	// Consecutive failed attempts of the configuration identified by
+	// failedConfigHash.
+	Failures         int32               `protobuf:"varint,8,opt,name=failures,proto3" json:"failures,omitempty"`
+	FailedConfigHash string              `protobuf:"bytes,9,opt,name=failedConfigHash,proto3" json:"failedConfigHash,omitempty"`
+	LastRollback     *HelmRollbackStatus `protobuf:"bytes,10,opt,name=lastRollback,proto3" json:"lastRollback,omitempty"`
+	// Ready, Reconciling, Stalled, Degraded and DependenciesReady conditions of
+	// the component.
+	Conditions []*Condition `protobuf:"bytes,11,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// SHA-256 of the values of the deployed release.
+	AppliedValuesHash string `protobuf:"bytes,12,opt,name=appliedValuesHash,proto3" json:"appliedValuesHash,omitempty"`
+	// SHA-256 of the chart archive the release was last installed or upgraded
+	// with.
+	ChartDigest string `protobuf:"bytes,13,opt,name=chartDigest,proto3" json:"chartDigest,omitempty"`
+	// Namespace the release is installed in.
+	Namespace string `protobuf:"bytes,14,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Changes of the release waiting for approval in plan mode.
+	Plan *HelmComponentPlanStatus `protobuf:"bytes,15,opt,name=plan,proto3" json:"plan,omitempty"`
+	// Result of the helm tests run after the last install or upgrade.
+	LastTest *HelmTestStatus `protobuf:"bytes,16,opt,name=lastTest,proto3" json:"lastTest,omitempty"`
+	// CRDs of the chart applied by the crds policy.
+	Crds []*HelmCRDStatus `protobuf:"bytes,17,rep,name=crds,proto3" json:"crds,omitempty"`
+	// SHA-256 of the patches of the deployed release.
+	AppliedPatchesHash string `protobuf:"bytes,18,opt,name=appliedPatchesHash,proto3" json:"appliedPatchesHash,omitempty"`
+	// Reason of the error in message, Forbidden when a request was denied by
+	// RBAC.
+	Reason string `protobuf:"bytes,19,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *HelmComponentStatus) Reset() {
+	*x = HelmComponentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmComponentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmComponentStatus) ProtoMessage() {}
+
+func (x *HelmComponentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmComponentStatus.ProtoReflect.Descriptor instead.
+func (*HelmComponentStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HelmComponentStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetResources() []*HelmResourceStatus {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *HelmComponentStatus) GetResourcesTotal() int32 {
+	if x != nil {
+		return x.ResourcesTotal
+	}
+	return 0
+}
+
+func (x *HelmComponentStatus) GetDriftedResources() int32 {
+	if x != nil {
+		return x.DriftedResources
+	}
+	return 0
+}
+
+func (x *HelmComponentStatus) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *HelmComponentStatus) GetFailedConfigHash() string {
+	if x != nil {
+		return x.FailedConfigHash
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetLastRollback() *HelmRollbackStatus {
+	if x != nil {
+		return x.LastRollback
+	}
+	return nil
+}
+
+func (x *HelmComponentStatus) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *HelmComponentStatus) GetAppliedValuesHash() string {
+	if x != nil {
+		return x.AppliedValuesHash
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetChartDigest() string {
+	if x != nil {
+		return x.ChartDigest
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetPlan() *HelmComponentPlanStatus {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *HelmComponentStatus) GetLastTest() *HelmTestStatus {
+	if x != nil {
+		return x.LastTest
+	}
+	return nil
+}
+
+func (x *HelmComponentStatus) GetCrds() []*HelmCRDStatus {
+	if x != nil {
+		return x.Crds
+	}
+	return nil
+}
+
+func (x *HelmComponentStatus) GetAppliedPatchesHash() string {
+	if x != nil {
+		return x.AppliedPatchesHash
+	}
+	return ""
+}
+
+func (x *HelmComponentStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type HelmCRDStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Versions served by the CRD.
+	Versions       []string `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	StorageVersion string   `protobuf:"bytes,3,opt,name=storageVersion,proto3" json:"storageVersion,omitempty"`
+}
+
+func (x *HelmCRDStatus) Reset() {
+	*x = HelmCRDStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmCRDStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmCRDStatus) ProtoMessage() {}
+
+func (x *HelmCRDStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmCRDStatus.ProtoReflect.Descriptor instead.
+func (*HelmCRDStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HelmCRDStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmCRDStatus) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *HelmCRDStatus) GetStorageVersion() string {
+	if x != nil {
+		return x.StorageVersion
+	}
+	return ""
+}
+
+type HelmTestStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Revision of the release which was tested.
+	Revision int32 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// +kubebuilder:validation:Enum=Succeeded;Failed
+	Result string                 `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Tests  []*HelmTestResult      `protobuf:"bytes,3,rep,name=tests,proto3" json:"tests,omitempty"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *HelmTestStatus) Reset() {
+	*x = HelmTestStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmTestStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmTestStatus) ProtoMessage() {}
+
+func (x *HelmTestStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmTestStatus.ProtoReflect.Descriptor instead.
+func (*HelmTestStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HelmTestStatus) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *HelmTestStatus) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *HelmTestStatus) GetTests() []*HelmTestResult {
+	if x != nil {
+		return x.Tests
+	}
+	return nil
+}
+
+func (x *HelmTestStatus) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type HelmTestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the test pod.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Phase of the test pod: Succeeded, Failed, Running or Unknown.
+	Phase string `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	// Last lines of the logs of the test pod.
+	Log string `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+}
+
+func (x *HelmTestResult) Reset() {
+	*x = HelmTestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmTestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmTestResult) ProtoMessage() {}
+
+func (x *HelmTestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmTestResult.ProtoReflect.Descriptor instead.
+func (*HelmTestResult) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HelmTestResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmTestResult) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *HelmTestResult) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+type HelmComponentPlanStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// +kubebuilder:validation:Enum=install;upgrade
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// Revision of the release the plan was computed against, 0 for an install.
+	Revision int32                 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	Changes  []*HelmResourceChange `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Unified diff of the release manifest, left empty when it is stored in
+	// the diffConfigMap of the HelmApp plan.
+	Diff string `protobuf:"bytes,5,opt,name=diff,proto3" json:"diff,omitempty"`
+}
+
+func (x *HelmComponentPlanStatus) Reset() {
+	*x = HelmComponentPlanStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmComponentPlanStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmComponentPlanStatus) ProtoMessage() {}
+
+func (x *HelmComponentPlanStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmComponentPlanStatus.ProtoReflect.Descriptor instead.
+func (*HelmComponentPlanStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HelmComponentPlanStatus) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *HelmComponentPlanStatus) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HelmComponentPlanStatus) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *HelmComponentPlanStatus) GetChanges() []*HelmResourceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *HelmComponentPlanStatus) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+type HelmResourceChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// +kubebuilder:validation:Enum=create;update;delete
+	Action    string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *HelmResourceChange) Reset() {
+	*x = HelmResourceChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmResourceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmResourceChange) ProtoMessage() {}
+
+func (x *HelmResourceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmResourceChange.ProtoReflect.Descriptor instead.
+func (*HelmResourceChange) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *HelmResourceChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HelmResourceChange) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *HelmResourceChange) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HelmResourceChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Condition follows the metav1.Condition conventions.
+type Condition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Generation of the HelmApp the condition was computed from.
+	// +kubebuilder:validation:XIntOrString
+	ObservedGeneration int64                  `protobuf:"varint,3,opt,name=observedGeneration,proto3" json:"observedGeneration,omitempty"`
+	LastTransitionTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=lastTransitionTime,proto3" json:"lastTransitionTime,omitempty"`
+	// CamelCase reason of the last transition.
+	Reason  string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Condition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Condition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Condition) GetObservedGeneration() int64 {
+	if x != nil {
+		return x.ObservedGeneration
+	}
+	return 0
+}
+
+func (x *Condition) GetLastTransitionTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTransitionTime
+	}
+	return nil
+}
+
+func (x *Condition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Condition) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// The last rollback of a failed upgrade.
+type HelmRollbackStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Revision which failed and was rolled back.
+	FromRevision int32 `protobuf:"varint,1,opt,name=fromRevision,proto3" json:"fromRevision,omitempty"`
+	// Revision the release was rolled back to.
+	ToRevision int32                  `protobuf:"varint,2,opt,name=toRevision,proto3" json:"toRevision,omitempty"`
+	Reason     string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Time       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *HelmRollbackStatus) Reset() {
+	*x = HelmRollbackStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmRollbackStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmRollbackStatus) ProtoMessage() {}
+
+func (x *HelmRollbackStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmRollbackStatus.ProtoReflect.Descriptor instead.
+func (*HelmRollbackStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HelmRollbackStatus) GetFromRevision() int32 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+func (x *HelmRollbackStatus) GetToRevision() int32 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+func (x *HelmRollbackStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *HelmRollbackStatus) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type HelmResourceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Health of the live object.
+	// +kubebuilder:validation:Enum=Current;InProgress;Failed;NotFound;Unknown
+	Health  string `protobuf:"bytes,5,opt,name=health,proto3" json:"health,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the live object drifted from the release manifest.
+	Drifted      bool   `protobuf:"varint,7,opt,name=drifted,proto3" json:"drifted,omitempty"`
+	DriftMessage string `protobuf:"bytes,8,opt,name=driftMessage,proto3" json:"driftMessage,omitempty"`
+	// Whether the resource is kept when the release is uninstalled, by the
+	// helm.sh/resource-policy: keep annotation or the deletion policy.
+	Keep bool `protobuf:"varint,9,opt,name=keep,proto3" json:"keep,omitempty"`
+}
+
+func (x *HelmResourceStatus) Reset() {
+	*x = HelmResourceStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmResourceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmResourceStatus) ProtoMessage() {}
+
+func (x *HelmResourceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1alpha1_helmapp_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmResourceStatus.ProtoReflect.Descriptor instead.
+func (*HelmResourceStatus) Descriptor() ([]byte, []int) {
+	return file_operator_v1alpha1_helmapp_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *HelmResourceStatus) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetDrifted() bool {
+	if x != nil {
+		return x.Drifted
+	}
+	return false
+}
+
+func (x *HelmResourceStatus) GetDriftMessage() string {
+	if x != nil {
+		return x.DriftMessage
+	}
+	return ""
+}
+
+func (x *HelmResourceStatus) GetKeep() bool {
+	if x != nil {
+		return x.Keep
+	}
+	return false
+}
+
+var File_operator_v1alpha1_helmapp_proto protoreflect.FileDescriptor
+
+var file_operator_v1alpha1_helmapp_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2f, 0x68, 0x65, 0x6c, 0x6d, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x17, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x04, 0x0a, 0x0b, 0x48, 0x65,
+	0x6c, 0x6d, 0x41, 0x70, 0x70, 0x53, 0x70, 0x65, 0x63, 0x12, 0x46, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
 	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x6f, 0x6d, 0x70,
 	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
 	0x73, 0x12, 0x3b, 0x0a, 0x0c, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65,
@@ -523,65 +2108,357 @@ var file_operator_v1alpha1_helmapp_proto_rawDesc = []byte{
 	0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70,
 	0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
 	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x52,
-	0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0xfd, 0x01, 0x0a, 0x0d, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x6f,
-	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
-	0x68, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x61, 0x72,
-	0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0f, 0x63,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0f, 0x63,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x35,
-	0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70,
-	0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x52,
-	0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x2e, 0x0a, 0x12, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x47,
-	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x12, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x30, 0x0a, 0x08, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x70,
-	0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x93, 0x01, 0x0a, 0x0d, 0x48, 0x65, 0x6c, 0x6d,
-	0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x70, 0x68, 0x61,
-	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61,
+	0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d,
+	0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x48, 0x0a,
+	0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65,
+	0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x70, 0x6c, 0x61, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x5d, 0x0a,
+	0x13, 0x6b, 0x75, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x6c, 0x75,
+	0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x13, 0x6b, 0x75, 0x62, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x2e, 0x0a, 0x12,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xfe, 0x06, 0x0a,
+	0x0d, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x68, 0x61, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65,
+	0x6c, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x2e, 0x0a, 0x12,
+	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x72,
+	0x69, 0x66, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x72, 0x69, 0x66, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x4c, 0x0a, 0x0d,
+	0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0d, 0x75, 0x70, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x48, 0x0a, 0x0a, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x28,
+	0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x11, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x37, 0x0a, 0x04,
+	0x74, 0x65, 0x73, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x6c, 0x75,
+	0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x04, 0x74, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x72, 0x64, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x64,
+	0x73, 0x12, 0x42, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x74,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x79, 0x0a,
+	0x0f, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x3c, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x63, 0x68,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x22, 0x79, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x63,
+	0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x22, 0x87, 0x01, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x2c, 0x0a, 0x11, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4f, 0x6e, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x72, 0x6f, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0xbd, 0x02,
+	0x0a, 0x11, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x4e, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x5d, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61,
 	0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12,
-	0x4c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a,
+	0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x93, 0x01,
+	0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x22, 0xd0, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x12, 0x2c, 0x0a,
+	0x11, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61,
+	0x63, 0x6b, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63,
+	0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4f, 0x6e, 0x46, 0x61, 0x69,
+	0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xb4, 0x02, 0x0a, 0x08, 0x48, 0x65, 0x6c, 0x6d, 0x52,
+	0x65, 0x70, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x4b, 0x0a, 0x09, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70,
+	0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x4f, 0x0a, 0x0b, 0x63, 0x61, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x52, 0x65, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70, 0x6c,
+	0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x63, 0x61, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x34, 0x0a, 0x15, 0x69, 0x6e, 0x73, 0x65, 0x63,
+	0x75, 0x72, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65,
+	0x53, 0x6b, 0x69, 0x70, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x2e, 0x0a,
+	0x12, 0x70, 0x61, 0x73, 0x73, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
+	0x41, 0x6c, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x70, 0x61, 0x73, 0x73, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x41, 0x6c, 0x6c, 0x22, 0x2a, 0x0a,
+	0x14, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x12, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xdd, 0x03, 0x0a, 0x0d, 0x48, 0x65, 0x6c, 0x6d, 0x41, 0x70,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x4c, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x2e, 0x0a, 0x12, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x48, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x4a, 0x0a, 0x12, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75,
+	0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72,
 	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65,
-	0x6c, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xe8, 0x01,
-	0x0a, 0x13, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61,
+	0x6c, 0x6d, 0x41, 0x70, 0x70, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x04, 0x70, 0x6c, 0x61, 0x6e, 0x22, 0x4d, 0x0a, 0x11, 0x48, 0x65, 0x6c, 0x6d, 0x41, 0x70, 0x70,
+	0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x24,
+	0x0a, 0x0d, 0x64, 0x69, 0x66, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x69, 0x66, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x4d, 0x61, 0x70, 0x22, 0xee, 0x06, 0x0a, 0x13, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x09,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x2a, 0x0a, 0x10, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x64, 0x72, 0x69, 0x66, 0x74,
+	0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x4f, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x6c, 0x75, 0x6d,
+	0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x12, 0x42, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61,
 	0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x7a, 0x0a, 0x12, 0x48, 0x65, 0x6c, 0x6d,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e,
-	0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12,
-	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x2a, 0x4e, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x0b, 0x0a,
-	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45,
-	0x43, 0x4f, 0x4e, 0x43, 0x49, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53,
-	0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41,
-	0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x49,
-	0x4e, 0x47, 0x10, 0x04, 0x42, 0x20, 0x5a, 0x1e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x69, 0x6f,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x48, 0x61, 0x73, 0x68, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x72, 0x74, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x61,
+	0x72, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48,
+	0x65, 0x6c, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x12, 0x43, 0x0a, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27,
+	0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x54, 0x65, 0x73,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x65, 0x73,
+	0x74, 0x12, 0x3a, 0x0a, 0x04, 0x63, 0x72, 0x64, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x52,
+	0x44, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x63, 0x72, 0x64, 0x73, 0x12, 0x2e, 0x0a,
+	0x12, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x67, 0x0a, 0x0d, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x52, 0x44,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xb3,
+	0x01, 0x0a, 0x0e, 0x48, 0x65, 0x6c, 0x6d, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3d, 0x0a, 0x05, 0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x48,
+	0x65, 0x6c, 0x6d, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x22, 0x4c, 0x0a, 0x0e, 0x48, 0x65, 0x6c, 0x6d, 0x54, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c,
+	0x6f, 0x67, 0x22, 0xbc, 0x01, 0x0a, 0x17, 0x48, 0x65, 0x6c, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x66,
+	0x66, 0x22, 0x72, 0x0a, 0x12, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xe5, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x2e, 0x0a, 0x12, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x4a, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa0, 0x01,
+	0x0a, 0x12, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x22, 0xfe, 0x01, 0x0a, 0x12, 0x48, 0x65, 0x6c, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x72, 0x69,
+	0x66, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x64, 0x72, 0x69, 0x66, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6b, 0x65, 0x65, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6b, 0x65, 0x65,
+	0x70, 0x2a, 0x4e, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x43, 0x4f, 0x4e,
+	0x43, 0x49, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x43, 0x43,
+	0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x49, 0x4e, 0x47, 0x10,
+	0x04, 0x42, 0x20, 0x5a, 0x1e, 0x70, 0x6c, 0x75, 0x6d, 0x61, 0x2e, 0x69, 0x6f, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -597,31 +2474,79 @@ func file_operator_v1alpha1_helmapp_proto_rawDescGZIP() []byte {
 }
 
 var file_operator_v1alpha1_helmapp_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_operator_v1alpha1_helmapp_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_operator_v1alpha1_helmapp_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_operator_v1alpha1_helmapp_proto_goTypes = []interface{}{
-	(Phase)(0),                  // 0: pluma.operator.v1alpha1.Phase
-	(*HelmAppSpec)(nil),         // 1: pluma.operator.v1alpha1.HelmAppSpec
-	(*HelmComponent)(nil),       // 2: pluma.operator.v1alpha1.HelmComponent
-	(*HelmRepo)(nil),            // 3: pluma.operator.v1alpha1.HelmRepo
-	(*HelmAppStatus)(nil),       // 4: pluma.operator.v1alpha1.HelmAppStatus
-	(*HelmComponentStatus)(nil), // 5: pluma.operator.v1alpha1.HelmComponentStatus
-	(*HelmResourceStatus)(nil),  // 6: pluma.operator.v1alpha1.HelmResourceStatus
-	(*structpb.Struct)(nil),     // 7: google.protobuf.Struct
+	(Phase)(0),                      // 0: pluma.operator.v1alpha1.Phase
+	(*HelmAppSpec)(nil),             // 1: pluma.operator.v1alpha1.HelmAppSpec
+	(*HelmComponent)(nil),           // 2: pluma.operator.v1alpha1.HelmComponent
+	(*PostRenderPatch)(nil),         // 3: pluma.operator.v1alpha1.PostRenderPatch
+	(*PatchTarget)(nil),             // 4: pluma.operator.v1alpha1.PatchTarget
+	(*TestPolicy)(nil),              // 5: pluma.operator.v1alpha1.TestPolicy
+	(*NamespaceMetadata)(nil),       // 6: pluma.operator.v1alpha1.NamespaceMetadata
+	(*ValuesReference)(nil),         // 7: pluma.operator.v1alpha1.ValuesReference
+	(*UpgradePolicy)(nil),           // 8: pluma.operator.v1alpha1.UpgradePolicy
+	(*HelmRepo)(nil),                // 9: pluma.operator.v1alpha1.HelmRepo
+	(*LocalObjectReference)(nil),    // 10: pluma.operator.v1alpha1.LocalObjectReference
+	(*SecretKeyReference)(nil),      // 11: pluma.operator.v1alpha1.SecretKeyReference
+	(*HelmAppStatus)(nil),           // 12: pluma.operator.v1alpha1.HelmAppStatus
+	(*HelmAppPlanStatus)(nil),       // 13: pluma.operator.v1alpha1.HelmAppPlanStatus
+	(*HelmComponentStatus)(nil),     // 14: pluma.operator.v1alpha1.HelmComponentStatus
+	(*HelmCRDStatus)(nil),           // 15: pluma.operator.v1alpha1.HelmCRDStatus
+	(*HelmTestStatus)(nil),          // 16: pluma.operator.v1alpha1.HelmTestStatus
+	(*HelmTestResult)(nil),          // 17: pluma.operator.v1alpha1.HelmTestResult
+	(*HelmComponentPlanStatus)(nil), // 18: pluma.operator.v1alpha1.HelmComponentPlanStatus
+	(*HelmResourceChange)(nil),      // 19: pluma.operator.v1alpha1.HelmResourceChange
+	(*Condition)(nil),               // 20: pluma.operator.v1alpha1.Condition
+	(*HelmRollbackStatus)(nil),      // 21: pluma.operator.v1alpha1.HelmRollbackStatus
+	(*HelmResourceStatus)(nil),      // 22: pluma.operator.v1alpha1.HelmResourceStatus
+	nil,                             // 23: pluma.operator.v1alpha1.NamespaceMetadata.LabelsEntry
+	nil,                             // 24: pluma.operator.v1alpha1.NamespaceMetadata.AnnotationsEntry
+	(*structpb.Struct)(nil),         // 25: google.protobuf.Struct
+	(*durationpb.Duration)(nil),     // 26: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 27: google.protobuf.Timestamp
 }
 var file_operator_v1alpha1_helmapp_proto_depIdxs = []int32{
-	2, // 0: pluma.operator.v1alpha1.HelmAppSpec.components:type_name -> pluma.operator.v1alpha1.HelmComponent
-	7, // 1: pluma.operator.v1alpha1.HelmAppSpec.globalValues:type_name -> google.protobuf.Struct
-	3, // 2: pluma.operator.v1alpha1.HelmAppSpec.repo:type_name -> pluma.operator.v1alpha1.HelmRepo
-	7, // 3: pluma.operator.v1alpha1.HelmComponent.componentValues:type_name -> google.protobuf.Struct
-	3, // 4: pluma.operator.v1alpha1.HelmComponent.repo:type_name -> pluma.operator.v1alpha1.HelmRepo
-	0, // 5: pluma.operator.v1alpha1.HelmAppStatus.phase:type_name -> pluma.operator.v1alpha1.Phase
-	5, // 6: pluma.operator.v1alpha1.HelmAppStatus.components:type_name -> pluma.operator.v1alpha1.HelmComponentStatus
-	6, // 7: pluma.operator.v1alpha1.HelmComponentStatus.resources:type_name -> pluma.operator.v1alpha1.HelmResourceStatus
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2,  // 0: pluma.operator.v1alpha1.HelmAppSpec.components:type_name -> pluma.operator.v1alpha1.HelmComponent
+	25, // 1: pluma.operator.v1alpha1.HelmAppSpec.globalValues:type_name -> google.protobuf.Struct
+	9,  // 2: pluma.operator.v1alpha1.HelmAppSpec.repo:type_name -> pluma.operator.v1alpha1.HelmRepo
+	7,  // 3: pluma.operator.v1alpha1.HelmAppSpec.valuesFrom:type_name -> pluma.operator.v1alpha1.ValuesReference
+	11, // 4: pluma.operator.v1alpha1.HelmAppSpec.kubeConfigSecretRef:type_name -> pluma.operator.v1alpha1.SecretKeyReference
+	25, // 5: pluma.operator.v1alpha1.HelmComponent.componentValues:type_name -> google.protobuf.Struct
+	9,  // 6: pluma.operator.v1alpha1.HelmComponent.repo:type_name -> pluma.operator.v1alpha1.HelmRepo
+	8,  // 7: pluma.operator.v1alpha1.HelmComponent.upgradePolicy:type_name -> pluma.operator.v1alpha1.UpgradePolicy
+	7,  // 8: pluma.operator.v1alpha1.HelmComponent.valuesFrom:type_name -> pluma.operator.v1alpha1.ValuesReference
+	6,  // 9: pluma.operator.v1alpha1.HelmComponent.namespaceMetadata:type_name -> pluma.operator.v1alpha1.NamespaceMetadata
+	5,  // 10: pluma.operator.v1alpha1.HelmComponent.test:type_name -> pluma.operator.v1alpha1.TestPolicy
+	3,  // 11: pluma.operator.v1alpha1.HelmComponent.patches:type_name -> pluma.operator.v1alpha1.PostRenderPatch
+	4,  // 12: pluma.operator.v1alpha1.PostRenderPatch.target:type_name -> pluma.operator.v1alpha1.PatchTarget
+	26, // 13: pluma.operator.v1alpha1.TestPolicy.timeout:type_name -> google.protobuf.Duration
+	23, // 14: pluma.operator.v1alpha1.NamespaceMetadata.labels:type_name -> pluma.operator.v1alpha1.NamespaceMetadata.LabelsEntry
+	24, // 15: pluma.operator.v1alpha1.NamespaceMetadata.annotations:type_name -> pluma.operator.v1alpha1.NamespaceMetadata.AnnotationsEntry
+	26, // 16: pluma.operator.v1alpha1.UpgradePolicy.timeout:type_name -> google.protobuf.Duration
+	10, // 17: pluma.operator.v1alpha1.HelmRepo.secretRef:type_name -> pluma.operator.v1alpha1.LocalObjectReference
+	10, // 18: pluma.operator.v1alpha1.HelmRepo.caSecretRef:type_name -> pluma.operator.v1alpha1.LocalObjectReference
+	0,  // 19: pluma.operator.v1alpha1.HelmAppStatus.phase:type_name -> pluma.operator.v1alpha1.Phase
+	14, // 20: pluma.operator.v1alpha1.HelmAppStatus.components:type_name -> pluma.operator.v1alpha1.HelmComponentStatus
+	20, // 21: pluma.operator.v1alpha1.HelmAppStatus.conditions:type_name -> pluma.operator.v1alpha1.Condition
+	27, // 22: pluma.operator.v1alpha1.HelmAppStatus.lastAttemptedTime:type_name -> google.protobuf.Timestamp
+	27, // 23: pluma.operator.v1alpha1.HelmAppStatus.lastSuccessfulTime:type_name -> google.protobuf.Timestamp
+	13, // 24: pluma.operator.v1alpha1.HelmAppStatus.plan:type_name -> pluma.operator.v1alpha1.HelmAppPlanStatus
+	22, // 25: pluma.operator.v1alpha1.HelmComponentStatus.resources:type_name -> pluma.operator.v1alpha1.HelmResourceStatus
+	21, // 26: pluma.operator.v1alpha1.HelmComponentStatus.lastRollback:type_name -> pluma.operator.v1alpha1.HelmRollbackStatus
+	20, // 27: pluma.operator.v1alpha1.HelmComponentStatus.conditions:type_name -> pluma.operator.v1alpha1.Condition
+	18, // 28: pluma.operator.v1alpha1.HelmComponentStatus.plan:type_name -> pluma.operator.v1alpha1.HelmComponentPlanStatus
+	16, // 29: pluma.operator.v1alpha1.HelmComponentStatus.lastTest:type_name -> pluma.operator.v1alpha1.HelmTestStatus
+	15, // 30: pluma.operator.v1alpha1.HelmComponentStatus.crds:type_name -> pluma.operator.v1alpha1.HelmCRDStatus
+	17, // 31: pluma.operator.v1alpha1.HelmTestStatus.tests:type_name -> pluma.operator.v1alpha1.HelmTestResult
+	27, // 32: pluma.operator.v1alpha1.HelmTestStatus.time:type_name -> google.protobuf.Timestamp
+	19, // 33: pluma.operator.v1alpha1.HelmComponentPlanStatus.changes:type_name -> pluma.operator.v1alpha1.HelmResourceChange
+	27, // 34: pluma.operator.v1alpha1.Condition.lastTransitionTime:type_name -> google.protobuf.Timestamp
+	27, // 35: pluma.operator.v1alpha1.HelmRollbackStatus.time:type_name -> google.protobuf.Timestamp
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_operator_v1alpha1_helmapp_proto_init() }
@@ -655,7 +2580,7 @@ func file_operator_v1alpha1_helmapp_proto_init() {
 			}
 		}
 		file_operator_v1alpha1_helmapp_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HelmRepo); i {
+			switch v := v.(*PostRenderPatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -667,7 +2592,7 @@ func file_operator_v1alpha1_helmapp_proto_init() {
 			}
 		}
 		file_operator_v1alpha1_helmapp_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HelmAppStatus); i {
+			switch v := v.(*PatchTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -679,7 +2604,7 @@ func file_operator_v1alpha1_helmapp_proto_init() {
 			}
 		}
 		file_operator_v1alpha1_helmapp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HelmComponentStatus); i {
+			switch v := v.(*TestPolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -691,6 +2616,198 @@ func file_operator_v1alpha1_helmapp_proto_init() {
 			}
 		}
 		file_operator_v1alpha1_helmapp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamespaceMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValuesReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradePolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmRepo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalObjectReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretKeyReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmAppStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmAppPlanStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmComponentStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmCRDStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmTestStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmTestResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmComponentPlanStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmResourceChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Condition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmRollbackStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_operator_v1alpha1_helmapp_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HelmResourceStatus); i {
 			case 0:
 				return &v.state
@@ -709,7 +2826,7 @@ func file_operator_v1alpha1_helmapp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_operator_v1alpha1_helmapp_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
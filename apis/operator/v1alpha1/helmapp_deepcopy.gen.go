@@ -47,6 +47,132 @@ func (in *HelmComponent) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using PostRenderPatch within kubernetes types, where deepcopy-gen is used.
+func (in *PostRenderPatch) DeepCopyInto(out *PostRenderPatch) {
+	p := proto.Clone(in).(*PostRenderPatch)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostRenderPatch. Required by controller-gen.
+func (in *PostRenderPatch) DeepCopy() *PostRenderPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PostRenderPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new PostRenderPatch. Required by controller-gen.
+func (in *PostRenderPatch) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using PatchTarget within kubernetes types, where deepcopy-gen is used.
+func (in *PatchTarget) DeepCopyInto(out *PatchTarget) {
+	p := proto.Clone(in).(*PatchTarget)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchTarget. Required by controller-gen.
+func (in *PatchTarget) DeepCopy() *PatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new PatchTarget. Required by controller-gen.
+func (in *PatchTarget) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using TestPolicy within kubernetes types, where deepcopy-gen is used.
+func (in *TestPolicy) DeepCopyInto(out *TestPolicy) {
+	p := proto.Clone(in).(*TestPolicy)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestPolicy. Required by controller-gen.
+func (in *TestPolicy) DeepCopy() *TestPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TestPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new TestPolicy. Required by controller-gen.
+func (in *TestPolicy) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using NamespaceMetadata within kubernetes types, where deepcopy-gen is used.
+func (in *NamespaceMetadata) DeepCopyInto(out *NamespaceMetadata) {
+	p := proto.Clone(in).(*NamespaceMetadata)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceMetadata. Required by controller-gen.
+func (in *NamespaceMetadata) DeepCopy() *NamespaceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceMetadata. Required by controller-gen.
+func (in *NamespaceMetadata) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ValuesReference within kubernetes types, where deepcopy-gen is used.
+func (in *ValuesReference) DeepCopyInto(out *ValuesReference) {
+	p := proto.Clone(in).(*ValuesReference)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValuesReference. Required by controller-gen.
+func (in *ValuesReference) DeepCopy() *ValuesReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ValuesReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ValuesReference. Required by controller-gen.
+func (in *ValuesReference) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using UpgradePolicy within kubernetes types, where deepcopy-gen is used.
+func (in *UpgradePolicy) DeepCopyInto(out *UpgradePolicy) {
+	p := proto.Clone(in).(*UpgradePolicy)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy. Required by controller-gen.
+func (in *UpgradePolicy) DeepCopy() *UpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy. Required by controller-gen.
+func (in *UpgradePolicy) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using HelmRepo within kubernetes types, where deepcopy-gen is used.
 func (in *HelmRepo) DeepCopyInto(out *HelmRepo) {
 	p := proto.Clone(in).(*HelmRepo)
@@ -68,6 +194,48 @@ func (in *HelmRepo) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using LocalObjectReference within kubernetes types, where deepcopy-gen is used.
+func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
+	p := proto.Clone(in).(*LocalObjectReference)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalObjectReference. Required by controller-gen.
+func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new LocalObjectReference. Required by controller-gen.
+func (in *LocalObjectReference) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using SecretKeyReference within kubernetes types, where deepcopy-gen is used.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	p := proto.Clone(in).(*SecretKeyReference)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference. Required by controller-gen.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference. Required by controller-gen.
+func (in *SecretKeyReference) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using HelmAppStatus within kubernetes types, where deepcopy-gen is used.
 func (in *HelmAppStatus) DeepCopyInto(out *HelmAppStatus) {
 	p := proto.Clone(in).(*HelmAppStatus)
@@ -89,6 +257,27 @@ func (in *HelmAppStatus) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using HelmAppPlanStatus within kubernetes types, where deepcopy-gen is used.
+func (in *HelmAppPlanStatus) DeepCopyInto(out *HelmAppPlanStatus) {
+	p := proto.Clone(in).(*HelmAppPlanStatus)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmAppPlanStatus. Required by controller-gen.
+func (in *HelmAppPlanStatus) DeepCopy() *HelmAppPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmAppPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmAppPlanStatus. Required by controller-gen.
+func (in *HelmAppPlanStatus) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using HelmComponentStatus within kubernetes types, where deepcopy-gen is used.
 func (in *HelmComponentStatus) DeepCopyInto(out *HelmComponentStatus) {
 	p := proto.Clone(in).(*HelmComponentStatus)
@@ -110,6 +299,153 @@ func (in *HelmComponentStatus) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using HelmCRDStatus within kubernetes types, where deepcopy-gen is used.
+func (in *HelmCRDStatus) DeepCopyInto(out *HelmCRDStatus) {
+	p := proto.Clone(in).(*HelmCRDStatus)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmCRDStatus. Required by controller-gen.
+func (in *HelmCRDStatus) DeepCopy() *HelmCRDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmCRDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmCRDStatus. Required by controller-gen.
+func (in *HelmCRDStatus) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using HelmTestStatus within kubernetes types, where deepcopy-gen is used.
+func (in *HelmTestStatus) DeepCopyInto(out *HelmTestStatus) {
+	p := proto.Clone(in).(*HelmTestStatus)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmTestStatus. Required by controller-gen.
+func (in *HelmTestStatus) DeepCopy() *HelmTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmTestStatus. Required by controller-gen.
+func (in *HelmTestStatus) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using HelmTestResult within kubernetes types, where deepcopy-gen is used.
+func (in *HelmTestResult) DeepCopyInto(out *HelmTestResult) {
+	p := proto.Clone(in).(*HelmTestResult)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmTestResult. Required by controller-gen.
+func (in *HelmTestResult) DeepCopy() *HelmTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmTestResult. Required by controller-gen.
+func (in *HelmTestResult) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using HelmComponentPlanStatus within kubernetes types, where deepcopy-gen is used.
+func (in *HelmComponentPlanStatus) DeepCopyInto(out *HelmComponentPlanStatus) {
+	p := proto.Clone(in).(*HelmComponentPlanStatus)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmComponentPlanStatus. Required by controller-gen.
+func (in *HelmComponentPlanStatus) DeepCopy() *HelmComponentPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmComponentPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmComponentPlanStatus. Required by controller-gen.
+func (in *HelmComponentPlanStatus) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using HelmResourceChange within kubernetes types, where deepcopy-gen is used.
+func (in *HelmResourceChange) DeepCopyInto(out *HelmResourceChange) {
+	p := proto.Clone(in).(*HelmResourceChange)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmResourceChange. Required by controller-gen.
+func (in *HelmResourceChange) DeepCopy() *HelmResourceChange {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmResourceChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmResourceChange. Required by controller-gen.
+func (in *HelmResourceChange) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Condition within kubernetes types, where deepcopy-gen is used.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	p := proto.Clone(in).(*Condition)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition. Required by controller-gen.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Condition. Required by controller-gen.
+func (in *Condition) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using HelmRollbackStatus within kubernetes types, where deepcopy-gen is used.
+func (in *HelmRollbackStatus) DeepCopyInto(out *HelmRollbackStatus) {
+	p := proto.Clone(in).(*HelmRollbackStatus)
+	*out = *p
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmRollbackStatus. Required by controller-gen.
+func (in *HelmRollbackStatus) DeepCopy() *HelmRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new HelmRollbackStatus. Required by controller-gen.
+func (in *HelmRollbackStatus) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using HelmResourceStatus within kubernetes types, where deepcopy-gen is used.
 func (in *HelmResourceStatus) DeepCopyInto(out *HelmResourceStatus) {
 	p := proto.Clone(in).(*HelmResourceStatus)
@@ -29,6 +29,72 @@ func (this *HelmComponent) UnmarshalJSON(b []byte) error {
 	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
 }
 
+// MarshalJSON is a custom marshaler for PostRenderPatch
+func (this *PostRenderPatch) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for PostRenderPatch
+func (this *PostRenderPatch) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for PatchTarget
+func (this *PatchTarget) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for PatchTarget
+func (this *PatchTarget) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for TestPolicy
+func (this *TestPolicy) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for TestPolicy
+func (this *TestPolicy) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for NamespaceMetadata
+func (this *NamespaceMetadata) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for NamespaceMetadata
+func (this *NamespaceMetadata) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for ValuesReference
+func (this *ValuesReference) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ValuesReference
+func (this *ValuesReference) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for UpgradePolicy
+func (this *UpgradePolicy) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for UpgradePolicy
+func (this *UpgradePolicy) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
 // MarshalJSON is a custom marshaler for HelmRepo
 func (this *HelmRepo) MarshalJSON() ([]byte, error) {
 	str, err := HelmappMarshaler.MarshalToString(this)
@@ -40,6 +106,28 @@ func (this *HelmRepo) UnmarshalJSON(b []byte) error {
 	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
 }
 
+// MarshalJSON is a custom marshaler for LocalObjectReference
+func (this *LocalObjectReference) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for LocalObjectReference
+func (this *LocalObjectReference) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for SecretKeyReference
+func (this *SecretKeyReference) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for SecretKeyReference
+func (this *SecretKeyReference) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
 // MarshalJSON is a custom marshaler for HelmAppStatus
 func (this *HelmAppStatus) MarshalJSON() ([]byte, error) {
 	str, err := HelmappMarshaler.MarshalToString(this)
@@ -51,6 +139,17 @@ func (this *HelmAppStatus) UnmarshalJSON(b []byte) error {
 	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
 }
 
+// MarshalJSON is a custom marshaler for HelmAppPlanStatus
+func (this *HelmAppPlanStatus) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmAppPlanStatus
+func (this *HelmAppPlanStatus) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
 // MarshalJSON is a custom marshaler for HelmComponentStatus
 func (this *HelmComponentStatus) MarshalJSON() ([]byte, error) {
 	str, err := HelmappMarshaler.MarshalToString(this)
@@ -62,6 +161,83 @@ func (this *HelmComponentStatus) UnmarshalJSON(b []byte) error {
 	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
 }
 
+// MarshalJSON is a custom marshaler for HelmCRDStatus
+func (this *HelmCRDStatus) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmCRDStatus
+func (this *HelmCRDStatus) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for HelmTestStatus
+func (this *HelmTestStatus) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmTestStatus
+func (this *HelmTestStatus) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for HelmTestResult
+func (this *HelmTestResult) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmTestResult
+func (this *HelmTestResult) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for HelmComponentPlanStatus
+func (this *HelmComponentPlanStatus) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmComponentPlanStatus
+func (this *HelmComponentPlanStatus) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for HelmResourceChange
+func (this *HelmResourceChange) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmResourceChange
+func (this *HelmResourceChange) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for Condition
+func (this *Condition) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Condition
+func (this *Condition) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
+// MarshalJSON is a custom marshaler for HelmRollbackStatus
+func (this *HelmRollbackStatus) MarshalJSON() ([]byte, error) {
+	str, err := HelmappMarshaler.MarshalToString(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for HelmRollbackStatus
+func (this *HelmRollbackStatus) UnmarshalJSON(b []byte) error {
+	return HelmappUnmarshaler.Unmarshal(bytes.NewReader(b), this)
+}
+
 // MarshalJSON is a custom marshaler for HelmResourceStatus
 func (this *HelmResourceStatus) MarshalJSON() ([]byte, error) {
 	str, err := HelmappMarshaler.MarshalToString(this)
@@ -4,7 +4,9 @@
 * This file is a generated Typescript file for GRPC Gateway, DO NOT MODIFY
 */
 
+import * as GoogleProtobufDuration from "../../google/protobuf/duration.pb"
 import * as GoogleProtobufStruct from "../../google/protobuf/struct.pb"
+import * as GoogleProtobufTimestamp from "../../google/protobuf/timestamp.pb"
 
 export enum Phase {
   UNKNOWN = "UNKNOWN",
@@ -18,6 +20,13 @@ export type HelmAppSpec = {
   components?: HelmComponent[]
   globalValues?: GoogleProtobufStruct.Struct
   repo?: HelmRepo
+  maxConcurrency?: number
+  valuesFrom?: ValuesReference[]
+  suspend?: boolean
+  plan?: boolean
+  deletionPolicy?: string
+  kubeConfigSecretRef?: SecretKeyReference
+  serviceAccountName?: string
 }
 
 export type HelmComponent = {
@@ -27,16 +36,92 @@ export type HelmComponent = {
   componentValues?: GoogleProtobufStruct.Struct
   repo?: HelmRepo
   ignoreGlobalValues?: boolean
+  dependsOn?: string[]
+  driftPolicy?: string
+  upgradePolicy?: UpgradePolicy
+  valuesFrom?: ValuesReference[]
+  targetNamespace?: string
+  createNamespace?: boolean
+  namespaceMetadata?: NamespaceMetadata
+  suspend?: boolean
+  test?: TestPolicy
+  deletionPolicy?: string
+  crds?: string
+  patches?: PostRenderPatch[]
+  templateValues?: boolean
+}
+
+export type PostRenderPatch = {
+  target?: PatchTarget
+  type?: string
+  patch?: string
+}
+
+export type PatchTarget = {
+  kind?: string
+  name?: string
+  namespace?: string
+  labelSelector?: string
+}
+
+export type TestPolicy = {
+  enable?: boolean
+  timeout?: GoogleProtobufDuration.Duration
+  rollbackOnFailure?: boolean
+}
+
+export type NamespaceMetadata = {
+  labels?: {[key: string]: string}
+  annotations?: {[key: string]: string}
+}
+
+export type ValuesReference = {
+  kind?: string
+  name?: string
+  valuesKey?: string
+  targetPath?: string
+  optional?: boolean
+}
+
+export type UpgradePolicy = {
+  atomic?: boolean
+  rollbackOnFailure?: boolean
+  cleanupOnFail?: boolean
+  maxRetries?: number
+  timeout?: GoogleProtobufDuration.Duration
 }
 
 export type HelmRepo = {
   name?: string
   url?: string
+  secretRef?: LocalObjectReference
+  caSecretRef?: LocalObjectReference
+  insecureSkipTLSVerify?: boolean
+  passCredentialsAll?: boolean
+}
+
+export type LocalObjectReference = {
+  name?: string
+}
+
+export type SecretKeyReference = {
+  name?: string
+  key?: string
 }
 
 export type HelmAppStatus = {
   phase?: Phase
   components?: HelmComponentStatus[]
+  conditions?: Condition[]
+  observedGeneration?: string
+  lastAttemptedTime?: GoogleProtobufTimestamp.Timestamp
+  lastSuccessfulTime?: GoogleProtobufTimestamp.Timestamp
+  plan?: HelmAppPlanStatus
+}
+
+export type HelmAppPlanStatus = {
+  hash?: string
+  diffConfigMap?: string
 }
 
 export type HelmComponentStatus = {
@@ -46,6 +131,69 @@ export type HelmComponentStatus = {
   version?: string
   resources?: HelmResourceStatus[]
   resourcesTotal?: number
+  driftedResources?: number
+  failures?: number
+  failedConfigHash?: string
+  lastRollback?: HelmRollbackStatus
+  conditions?: Condition[]
+  appliedValuesHash?: string
+  chartDigest?: string
+  namespace?: string
+  plan?: HelmComponentPlanStatus
+  lastTest?: HelmTestStatus
+  crds?: HelmCRDStatus[]
+  appliedPatchesHash?: string
+  reason?: string
+}
+
+export type HelmCRDStatus = {
+  name?: string
+  versions?: string[]
+  storageVersion?: string
+}
+
+export type HelmTestStatus = {
+  revision?: number
+  result?: string
+  tests?: HelmTestResult[]
+  time?: GoogleProtobufTimestamp.Timestamp
+}
+
+export type HelmTestResult = {
+  name?: string
+  phase?: string
+  log?: string
+}
+
+export type HelmComponentPlanStatus = {
+  hash?: string
+  action?: string
+  revision?: number
+  changes?: HelmResourceChange[]
+  diff?: string
+}
+
+export type HelmResourceChange = {
+  action?: string
+  kind?: string
+  namespace?: string
+  name?: string
+}
+
+export type Condition = {
+  type?: string
+  status?: string
+  observedGeneration?: string
+  lastTransitionTime?: GoogleProtobufTimestamp.Timestamp
+  reason?: string
+  message?: string
+}
+
+export type HelmRollbackStatus = {
+  fromRevision?: number
+  toRevision?: number
+  reason?: string
+  time?: GoogleProtobufTimestamp.Timestamp
 }
 
 export type HelmResourceStatus = {
@@ -53,4 +201,9 @@ export type HelmResourceStatus = {
   kind?: string
   name?: string
   namespace?: string
+  health?: string
+  message?: string
+  drifted?: boolean
+  driftMessage?: string
+  keep?: boolean
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"os"
+	"path/filepath"
 
 	"pluma.io/pluma-opeartor/config"
 
@@ -42,6 +43,16 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&config.GlobalConfig.ProfilesDir, "profiles-dir", "./istio/profiles", "Directory containing Istio profiles")
+	flag.IntVar(&config.GlobalConfig.MaxConcurrentComponents, "max-concurrent-components", config.DefaultMaxConcurrentComponents,
+		"The default number of components of a HelmApp reconciled at the same time.")
+	flag.StringVar(&config.GlobalConfig.ClusterVariablesConfigMap, "cluster-variables-configmap", "",
+		"The namespace/name of the ConfigMap holding the cluster variables HelmApp values are templated with.")
+	flag.StringVar(&config.GlobalConfig.ChartCacheDir, "chart-cache-dir", filepath.Join(os.TempDir(), "pluma-charts"),
+		"The directory the downloaded chart archives are cached in, it is emptied on start.")
+	flag.IntVar(&config.GlobalConfig.ChartCacheMaxCharts, "chart-cache-max-charts", 64,
+		"The number of chart archives cached, 0 disables the cache.")
+	flag.Int64Var(&config.GlobalConfig.ChartCacheMaxBytes, "chart-cache-max-bytes", 512<<20,
+		"The size in bytes of the cached chart archives, 0 for no limit.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -67,6 +78,7 @@ func main() {
 	if err = (&controller.HelmAppReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Config: config.GlobalConfig,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HelmApp")
 		os.Exit(1)
@@ -1,8 +1,23 @@
 package config
 
+// DefaultMaxConcurrentComponents is the number of components of a HelmApp reconciled at the same
+// time when neither the HelmApp nor the operator set one
+const DefaultMaxConcurrentComponents = 4
+
 // Config holds global configuration for the operator
 type Config struct {
 	ProfilesDir string
+	// MaxConcurrentComponents is the default number of components of a HelmApp reconciled at the same time
+	MaxConcurrentComponents int
+	// ClusterVariablesConfigMap is the namespace/name of the ConfigMap holding the cluster variables
+	// HelmApp values are templated with
+	ClusterVariablesConfigMap string
+	// ChartCacheDir is the directory the downloaded chart archives are cached in
+	ChartCacheDir string
+	// ChartCacheMaxCharts is the number of chart archives cached, 0 disables the cache
+	ChartCacheMaxCharts int
+	// ChartCacheMaxBytes is the size of the cached chart archives, 0 for no limit
+	ChartCacheMaxBytes int64
 }
 
 // GlobalConfig is the global configuration instance
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	helmaction "helm.sh/helm/v3/pkg/action"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// actionConfigs holds the helm action configuration of every namespace the releases of a HelmApp
+// are installed in. Helm stores a release in its namespace and renders resources without a
+// namespace into it, so every namespace needs its own configuration.
+type actionConfigs struct {
+	mu      sync.Mutex
+	configs map[string]*helmaction.Configuration
+	// client is the client of the cluster the releases are installed in
+	client client.Client
+	// getter reaches the cluster, it is scoped to the namespace of every configuration
+	getter *restConfigGetter
+}
+
+func newActionConfigs(kubeClient client.Client, getter *restConfigGetter) *actionConfigs {
+	return &actionConfigs{
+		configs: make(map[string]*helmaction.Configuration),
+		client:  kubeClient,
+		getter:  getter,
+	}
+}
+
+// get returns the action configuration of the namespace, initializing it on first use. Every call
+// gets its own copy sharing the clients of the namespace: helm caches the capabilities of the cluster
+// in the configuration on first use without locking, so the components reconciled at the same time
+// can't share it.
+func (c *actionConfigs) get(namespace string) (*helmaction.Configuration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if helmCfg, ok := c.configs[namespace]; ok {
+		return copyActionConfiguration(helmCfg), nil
+	}
+
+	helmCfg, err := newActionConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("failed to new Helm action config: %v", err)
+	}
+	if err := helmCfg.Init(c.getter.inNamespace(namespace), namespace, "", debug); err != nil {
+		return nil, fmt.Errorf("failed to initialize Helm action config: %w", err)
+	}
+	c.configs[namespace] = helmCfg
+	return copyActionConfiguration(helmCfg), nil
+}
+
+// invalidate drops the cached resources of the cluster, like after creating CRDs, and the
+// capabilities helm cached in helmCfg on first use, so its next action renders with the new APIs.
+// The configurations of the pool never hold capabilities, get returns copies without them.
+func (c *actionConfigs) invalidate(helmCfg *helmaction.Configuration) {
+	c.getter.invalidate()
+	helmCfg.Capabilities = nil
+}
+
+// copyActionConfiguration returns a copy of the configuration without its cached capabilities.
+func copyActionConfiguration(helmCfg *helmaction.Configuration) *helmaction.Configuration {
+	copied := *helmCfg
+	copied.Capabilities = nil
+	return &copied
+}
+
+// actionConfigTTL is how long pooled action configurations are reused, so they pick up the
+// upgrades of their cluster
+const actionConfigTTL = 10 * time.Minute
+
+// actionConfigPool keeps the action configurations of every cluster and user the HelmApps install
+// their releases as, so reconciles reuse the initialized helm, registry and discovery clients. A
+// nil pool builds the configurations every time.
+type actionConfigPool struct {
+	mu      sync.Mutex
+	entries map[string]*pooledActionConfigs
+}
+
+type pooledActionConfigs struct {
+	configs *actionConfigs
+	// credentials identifies the credentials the configurations were built with
+	credentials string
+	created     time.Time
+}
+
+func newActionConfigPool() *actionConfigPool {
+	return &actionConfigPool{entries: make(map[string]*pooledActionConfigs)}
+}
+
+// get returns the configurations of key. They are built again with build when they were built with
+// other credentials or expired.
+func (p *actionConfigPool) get(key, credentials string, build func() (*actionConfigs, error)) (*actionConfigs, error) {
+	if p == nil {
+		return build()
+	}
+	p.mu.Lock()
+	p.expire()
+	if entry, ok := p.entries[key]; ok && entry.credentials == credentials {
+		p.mu.Unlock()
+		return entry.configs, nil
+	}
+	p.mu.Unlock()
+
+	// Building the clients of a remote cluster can take a while, don't block the other clusters
+	configs, err := build()
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries[key] = &pooledActionConfigs{configs: configs, credentials: credentials, created: time.Now()}
+	return configs, nil
+}
+
+// remove drops the configurations of key, like when their cluster can't be reached anymore.
+func (p *actionConfigPool) remove(key string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, key)
+}
+
+// expire drops the expired configurations, including the ones nothing uses anymore.
+func (p *actionConfigPool) expire() {
+	for key, entry := range p.entries {
+		if time.Since(entry.created) > actionConfigTTL {
+			delete(p.entries, key)
+		}
+	}
+}
+
+// releaseNamespace returns the namespace the release of a component is installed in.
+func releaseNamespace(helmApp *operatorv1alpha1.HelmApp, targetNamespace string) string {
+	if targetNamespace != "" {
+		return targetNamespace
+	}
+	return helmApp.Namespace
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/client-go/rest"
+)
+
+func Test_actionConfigPool(t *testing.T) {
+	pool := newActionConfigPool()
+	builds := 0
+	build := func() (*actionConfigs, error) {
+		builds++
+		return newActionConfigs(nil, nil), nil
+	}
+
+	first, _ := pool.get("mesh/member/", "a", build)
+	if got, _ := pool.get("mesh/member/", "a", build); got != first || builds != 1 {
+		t.Errorf("get() built %d configurations, want the first one reused", builds)
+	}
+
+	// New credentials replace the configurations
+	rotated, _ := pool.get("mesh/member/", "b", build)
+	if rotated == first || builds != 2 {
+		t.Errorf("get() with new credentials built %d configurations, want new ones", builds)
+	}
+
+	// Other users of the cluster get their own configurations
+	if got, _ := pool.get("mesh/member/system:serviceaccount:mesh:deployer", "b", build); got == rotated || builds != 3 {
+		t.Errorf("get() of another user built %d configurations, want new ones", builds)
+	}
+
+	pool.remove("mesh/member/")
+	if got, _ := pool.get("mesh/member/", "b", build); got == rotated || builds != 4 {
+		t.Errorf("get() after remove() built %d configurations, want new ones", builds)
+	}
+
+	// Expired configurations are dropped, used or not
+	for _, entry := range pool.entries {
+		entry.created = time.Now().Add(-actionConfigTTL - time.Second)
+	}
+	pool.get("", "", build)
+	if len(pool.entries) != 1 || builds != 5 {
+		t.Errorf("pool holds %d configurations after expiry, want 1", len(pool.entries))
+	}
+}
+
+func Test_actionConfigs_get(t *testing.T) {
+	getter, err := newRESTConfigGetter(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs := newActionConfigs(nil, getter)
+	first, err := configs.get("istio-system")
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	first.Capabilities = chartutil.DefaultCapabilities
+
+	// Every component gets its own configuration, sharing the clients of the namespace
+	second, _ := configs.get("istio-system")
+	if second == first || second.Releases != first.Releases || second.Capabilities != nil {
+		t.Errorf("get() = %+v, want a copy of the configuration without capabilities", second)
+	}
+	if other, _ := configs.get("istio-ingress"); other.Releases == first.Releases {
+		t.Errorf("get() of another namespace shares the release storage")
+	}
+
+	// New CRDs are seen by the next action
+	configs.invalidate(first)
+	if first.Capabilities != nil {
+		t.Errorf("invalidate() kept the capabilities of the configuration")
+	}
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// chartKey identifies a chart version of a repo as pulled with some credentials.
+type chartKey struct {
+	repoURL string
+	chart   string
+	version string
+	// credentials is the digest of the credentials the chart was pulled with, a private chart is
+	// only served to the HelmApps holding the credentials of its repo
+	credentials string
+}
+
+// cachedChart is a chart archive kept in the cache directory.
+type cachedChart struct {
+	digest string
+	path   string
+	size   int64
+	// keys are the chart versions served by the archive, the same archive can be published in
+	// several repos
+	keys []chartKey
+}
+
+// chartCache keeps the archives of the charts installed by the operator, so the charts of
+// components which didn't change aren't downloaded again. Chart versions map to the digest of their
+// archive and the archives are stored by digest. The least recently used archives are evicted
+// beyond maxCharts archives or maxBytes bytes. A nil chartCache caches nothing.
+//
+// Loaded charts aren't shared, helm modifies them while installing, so a cached chart is loaded
+// from its archive every time it is used.
+type chartCache struct {
+	mu        sync.Mutex
+	dir       string
+	maxCharts int
+	maxBytes  int64
+
+	keys    map[chartKey]*list.Element
+	digests map[string]*list.Element
+	// lru holds the *cachedChart, the most recently used first
+	lru  *list.List
+	size int64
+}
+
+// newChartCache returns a cache storing at most maxCharts archives and maxBytes bytes in dir. The
+// directory is emptied, the cache doesn't outlive the operator. It returns nil, caching nothing,
+// if maxCharts is not positive.
+func newChartCache(dir string, maxCharts int, maxBytes int64) (*chartCache, error) {
+	if maxCharts <= 0 {
+		return nil, nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to clear chart cache %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create chart cache %s: %w", dir, err)
+	}
+	return &chartCache{
+		dir:       dir,
+		maxCharts: maxCharts,
+		maxBytes:  maxBytes,
+		keys:      map[chartKey]*list.Element{},
+		digests:   map[string]*list.Element{},
+		lru:       list.New(),
+	}, nil
+}
+
+// cacheable tells whether the chart version always refers to the same archive. Version ranges and
+// the latest version move as charts are published.
+func (k chartKey) cacheable() bool {
+	if k.repoURL == "" && !registry.IsOCI(k.chart) {
+		return false
+	}
+	_, err := semver.StrictNewVersion(k.version)
+	return err == nil
+}
+
+// load loads the cached chart of key and returns the digest of its archive. It returns nil if the
+// chart isn't cached.
+func (c *chartCache) load(key chartKey) (*helmchart.Chart, string) {
+	if c == nil {
+		return nil, ""
+	}
+	c.mu.Lock()
+	elem, ok := c.keys[key]
+	if !ok {
+		c.mu.Unlock()
+		return nil, ""
+	}
+	c.lru.MoveToFront(elem)
+	entry := elem.Value.(*cachedChart)
+	c.mu.Unlock()
+
+	chart, err := loader.Load(entry.path)
+	if err != nil {
+		// The archive can be evicted while it is loaded, let the caller download it again
+		return nil, ""
+	}
+	return chart, entry.digest
+}
+
+// add copies the downloaded archive of key into the cache and returns its digest. Charts which
+// can't be cached, like chart directories, are left where they are.
+func (c *chartCache) add(key chartKey, path string) (string, error) {
+	digest := chartDigest(path)
+	if c == nil || digest == "" || !key.cacheable() {
+		return digest, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.digests[digest]; ok {
+		c.addKey(elem, key)
+		return digest, nil
+	}
+
+	cached := filepath.Join(c.dir, digest+".tgz")
+	size, err := copyFile(path, cached)
+	if err != nil {
+		return "", fmt.Errorf("failed to cache chart: %w", err)
+	}
+	elem := c.lru.PushFront(&cachedChart{digest: digest, path: cached, size: size})
+	c.digests[digest] = elem
+	c.size += size
+	c.addKey(elem, key)
+	c.evict()
+	return digest, nil
+}
+
+// addKey maps key to the archive of elem, replacing the archive the key had.
+func (c *chartCache) addKey(elem *list.Element, key chartKey) {
+	if old, ok := c.keys[key]; ok {
+		if old == elem {
+			c.lru.MoveToFront(elem)
+			return
+		}
+		entry := old.Value.(*cachedChart)
+		for i, k := range entry.keys {
+			if k == key {
+				entry.keys = append(entry.keys[:i], entry.keys[i+1:]...)
+				break
+			}
+		}
+		if len(entry.keys) == 0 {
+			c.remove(old)
+		}
+	}
+	entry := elem.Value.(*cachedChart)
+	entry.keys = append(entry.keys, key)
+	c.keys[key] = elem
+	c.lru.MoveToFront(elem)
+}
+
+// evict removes the least recently used archives beyond the limits, keeping the newest one.
+func (c *chartCache) evict() {
+	for c.lru.Len() > 1 && (c.lru.Len() > c.maxCharts || (c.maxBytes > 0 && c.size > c.maxBytes)) {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *chartCache) remove(elem *list.Element) {
+	entry := elem.Value.(*cachedChart)
+	c.lru.Remove(elem)
+	delete(c.digests, entry.digest)
+	for _, key := range entry.keys {
+		delete(c.keys, key)
+	}
+	c.size -= entry.size
+	if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+		warning("failed to remove cached chart %s: %v", entry.path, err)
+	}
+}
+
+// chartDigest returns the SHA-256 of the chart archive, or an empty string for a chart directory.
+func chartDigest(path string) string {
+	if fi, err := os.Stat(path); err != nil || fi.IsDir() {
+		return ""
+	}
+	digest, err := provenance.DigestFile(path)
+	if err != nil {
+		return ""
+	}
+	return digest
+}
+
+func copyFile(src, dst string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return size, err
+}
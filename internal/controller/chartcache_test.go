@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// saveChart writes the archive of a chart to dir and returns its path.
+func saveChart(t *testing.T, dir, name, version string) string {
+	t.Helper()
+	chart := &helmchart.Chart{
+		Metadata:  &helmchart.Metadata{APIVersion: "v2", Name: name, Version: version},
+		Templates: []*helmchart.File{{Name: "templates/configmap.yaml", Data: []byte("kind: ConfigMap")}},
+	}
+	path, err := chartutil.Save(chart, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_chartCache(t *testing.T) {
+	downloads := t.TempDir()
+	cache, err := newChartCache(filepath.Join(t.TempDir(), "charts"), 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := chartKey{repoURL: "https://charts.example.com", chart: "base", version: "1.21.1"}
+	istiod := chartKey{repoURL: "https://charts.example.com", chart: "istiod", version: "1.21.1"}
+	mirror := chartKey{repoURL: "https://mirror.example.com", chart: "istiod", version: "1.21.1"}
+	gateway := chartKey{repoURL: "https://charts.example.com", chart: "gateway", version: "1.21.1"}
+
+	if chart, _ := cache.load(base); chart != nil {
+		t.Fatalf("load() of an empty cache = %v, want nil", chart)
+	}
+	basePath := saveChart(t, downloads, "base", "1.21.1")
+	digest, err := cache.add(base, basePath)
+	if err != nil || digest != chartDigest(basePath) {
+		t.Fatalf("add() = %q, %v, want the digest of the archive", digest, err)
+	}
+	// The cached archive doesn't depend on the download
+	if err := os.Remove(basePath); err != nil {
+		t.Fatal(err)
+	}
+	chart, cached := cache.load(base)
+	if chart == nil || chart.Name() != "base" || cached != digest {
+		t.Fatalf("load() = %v, %q, want base with digest %q", chart, cached, digest)
+	}
+
+	// The same archive from another repo is stored once
+	istiodPath := saveChart(t, downloads, "istiod", "1.21.1")
+	if _, err := cache.add(istiod, istiodPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.add(mirror, istiodPath); err != nil {
+		t.Fatal(err)
+	}
+	if cache.lru.Len() != 2 {
+		t.Errorf("cache holds %d archives, want 2", cache.lru.Len())
+	}
+
+	// base is the least recently used archive
+	if _, err := cache.add(gateway, saveChart(t, downloads, "gateway", "1.21.1")); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[chartKey]bool{base: false, istiod: true, mirror: true, gateway: true} {
+		if chart, _ := cache.load(key); (chart != nil) != want {
+			t.Errorf("load(%v) cached = %v, want %v", key, chart != nil, want)
+		}
+	}
+	entries, _ := os.ReadDir(cache.dir)
+	if len(entries) != 2 {
+		t.Errorf("cache directory holds %d files, want 2", len(entries))
+	}
+
+	// Archives beyond the size limit are evicted, keeping the newest one
+	cache.maxBytes = 1
+	if _, err := cache.add(base, saveChart(t, downloads, "base", "1.21.1")); err != nil {
+		t.Fatal(err)
+	}
+	if cache.lru.Len() != 1 || cache.size <= 0 {
+		t.Errorf("cache holds %d archives of %d bytes, want only the newest one", cache.lru.Len(), cache.size)
+	}
+}
+
+func Test_chartCache_credentials(t *testing.T) {
+	cache, err := newChartCache(filepath.Join(t.TempDir(), "charts"), 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := credentialsDigest(map[string][]byte{repoUsernameKey: []byte("tenant"), repoPasswordKey: []byte("secret")},
+		repoUsernameKey, repoPasswordKey)
+	other := credentialsDigest(map[string][]byte{repoUsernameKey: []byte("other"), repoPasswordKey: []byte("secret")},
+		repoUsernameKey, repoPasswordKey)
+	private := chartKey{repoURL: "https://charts.example.com", chart: "istiod", version: "1.21.1", credentials: tenant}
+	if _, err := cache.add(private, saveChart(t, t.TempDir(), "istiod", "1.21.1")); err != nil {
+		t.Fatal(err)
+	}
+
+	for credentials, want := range map[string]bool{"": false, other: false, tenant: true} {
+		key := private
+		key.credentials = credentials
+		if chart, _ := cache.load(key); (chart != nil) != want {
+			t.Errorf("load() with credentials %q cached = %v, want %v", credentials, chart != nil, want)
+		}
+	}
+}
+
+func Test_chartKey_cacheable(t *testing.T) {
+	tests := []struct {
+		key  chartKey
+		want bool
+	}{
+		{key: chartKey{repoURL: "https://charts.example.com", chart: "istiod", version: "1.21.1"}, want: true},
+		{key: chartKey{chart: "oci://registry.example.com/charts/istiod", version: "1.21.1"}, want: true},
+		{key: chartKey{repoURL: "https://charts.example.com", chart: "istiod", version: "~1.21"}},
+		{key: chartKey{repoURL: "https://charts.example.com", chart: "istiod"}},
+		{key: chartKey{chart: "./charts/istiod", version: "1.21.1"}},
+	}
+	for _, tt := range tests {
+		if got := tt.key.cacheable(); got != tt.want {
+			t.Errorf("cacheable(%v) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// kubeConfigKey is the key of the kubeconfig Secret read when the reference doesn't set one
+const kubeConfigKey = "value"
+
+// clusterTimeout bounds the requests to a remote cluster
+const clusterTimeout = 30 * time.Second
+
+// clusterActionConfigs returns the helm action configurations of the cluster the releases of the
+// HelmApp are installed in, acting as its ServiceAccount if it sets one. The configurations are
+// pooled by cluster and user, the ones of a remote cluster are built again when its kubeconfig
+// changes. A remote cluster is checked to be reachable, so connection errors are reported before
+// any release is touched.
+func (r *HelmAppReconciler) clusterActionConfigs(ctx context.Context, helmApp *operatorv1alpha1.HelmApp) (*actionConfigs, error) {
+	ref := helmApp.Spec.GetKubeConfigSecretRef()
+	user := ""
+	if serviceAccount := helmApp.Spec.GetServiceAccountName(); serviceAccount != "" {
+		user = serviceAccountUser(helmApp.Namespace, serviceAccount)
+	}
+
+	if ref.GetName() == "" {
+		if user == "" {
+			return r.actionConfigs.get("", "", func() (*actionConfigs, error) {
+				return newActionConfigs(r.Client, r.cluster), nil
+			})
+		}
+		// The ServiceAccount of a remote cluster can't be checked from here
+		serviceAccount := helmApp.Spec.GetServiceAccountName()
+		sa := &corev1.ServiceAccount{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: helmApp.Namespace, Name: serviceAccount}, sa); err != nil {
+			return nil, fmt.Errorf("failed to get ServiceAccount %s: %w", serviceAccount, err)
+		}
+		return r.actionConfigs.get(user, "", func() (*actionConfigs, error) {
+			return r.newClusterActionConfigs(r.cluster, user)
+		})
+	}
+
+	kubeConfig, credentials, err := r.readKubeConfig(ctx, helmApp.Namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s/%s/%s", helmApp.Namespace, ref.GetName(), user)
+	configs, err := r.actionConfigs.get(key, credentials, func() (*actionConfigs, error) {
+		restConfig, err := kubeConfigRESTConfig(kubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig in Secret %s: %w", ref.GetName(), err)
+		}
+		getter, err := newRESTConfigGetter(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to cluster %s: %w", restConfig.Host, err)
+		}
+		return r.newClusterActionConfigs(getter, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := configs.getter.discovery.ServerVersion(); err != nil {
+		// Start over once the cluster is back, its certificates may have changed
+		r.actionConfigs.remove(key)
+		return nil, fmt.Errorf("failed to connect to cluster %s: %w", configs.getter.config.Host, err)
+	}
+	return configs, nil
+}
+
+// newClusterActionConfigs returns the action configurations of the cluster reached with getter,
+// impersonating user if set.
+func (r *HelmAppReconciler) newClusterActionConfigs(getter *restConfigGetter, user string) (*actionConfigs, error) {
+	if user != "" {
+		getter = getter.impersonating(user)
+	}
+	kubeClient, err := client.New(getter.config, client.Options{Scheme: r.Scheme, Mapper: getter.mapper})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client of cluster %s: %w", getter.config.Host, err)
+	}
+	return newActionConfigs(kubeClient, getter), nil
+}
+
+// readKubeConfig reads the kubeconfig of a remote cluster from a Secret of the namespace. It also
+// returns the SHA-256 of the kubeconfig, which changes with the credentials.
+func (r *HelmAppReconciler) readKubeConfig(ctx context.Context, namespace string,
+	ref *operatorv1alpha1.SecretKeyReference) (*clientcmdapi.Config, string, error) {
+	key := ref.GetKey()
+	if key == "" {
+		key = kubeConfigKey
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.GetName()}, secret); err != nil {
+		return nil, "", fmt.Errorf("failed to get kubeconfig Secret %s: %w", ref.GetName(), err)
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, "", fmt.Errorf("kubeconfig Secret %s has no key %s", ref.GetName(), key)
+	}
+	kubeConfig, err := loadKubeConfig(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid kubeconfig in Secret %s: %w", ref.GetName(), err)
+	}
+	sum := sha256.Sum256(data)
+	return kubeConfig, hex.EncodeToString(sum[:]), nil
+}
+
+// serviceAccountUser returns the user name of a ServiceAccount.
+func serviceAccountUser(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
+
+// loadKubeConfig parses a kubeconfig read from a Secret. Credentials have to be inline: exec and
+// auth provider plugins would run commands in the operator and file references would read its
+// files, like its own service account token.
+func loadKubeConfig(data []byte) (*clientcmdapi.Config, error) {
+	kubeConfig, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	if kubeConfig.CurrentContext == "" {
+		return nil, fmt.Errorf("no current context")
+	}
+	for name, authInfo := range kubeConfig.AuthInfos {
+		switch {
+		case authInfo.Exec != nil:
+			return nil, fmt.Errorf("user %s: exec credential plugins are not allowed", name)
+		case authInfo.AuthProvider != nil:
+			return nil, fmt.Errorf("user %s: auth providers are not allowed", name)
+		case authInfo.TokenFile != "" || authInfo.ClientCertificate != "" || authInfo.ClientKey != "":
+			return nil, fmt.Errorf("user %s: credentials must be inline, files are not allowed", name)
+		}
+	}
+	for name, cluster := range kubeConfig.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return nil, fmt.Errorf("cluster %s: the certificate authority must be inline, files are not allowed", name)
+		}
+	}
+	return kubeConfig, nil
+}
+
+// kubeConfigRESTConfig returns the client config of the current context of the kubeconfig.
+func kubeConfigRESTConfig(kubeConfig *clientcmdapi.Config) (*rest.Config, error) {
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*kubeConfig, kubeConfig.CurrentContext, nil, nil).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = clusterTimeout
+	return restConfig, nil
+}
+
+// restConfigGetter is the RESTClientGetter of a cluster, scoped to a namespace. The discovery client
+// and the REST mapper are cached and shared by the getters of the cluster in every namespace.
+type restConfigGetter struct {
+	config    *rest.Config
+	namespace string
+	discovery discovery.CachedDiscoveryInterface
+	mapper    meta.RESTMapper
+}
+
+var _ genericclioptions.RESTClientGetter = &restConfigGetter{}
+
+func newRESTConfigGetter(config *rest.Config) (*restConfigGetter, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	cached := memory.NewMemCacheClient(discoveryClient)
+	return &restConfigGetter{
+		config:    config,
+		discovery: cached,
+		mapper:    restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cached), cached, nil),
+	}, nil
+}
+
+// inNamespace returns the getter of the cluster scoped to the namespace.
+func (g *restConfigGetter) inNamespace(namespace string) *restConfigGetter {
+	scoped := *g
+	scoped.namespace = namespace
+	return &scoped
+}
+
+// impersonating returns the getter of the cluster making the requests as user.
+func (g *restConfigGetter) impersonating(user string) *restConfigGetter {
+	impersonated := *g
+	impersonated.config = rest.CopyConfig(g.config)
+	impersonated.config.Impersonate = rest.ImpersonationConfig{UserName: user}
+	return &impersonated
+}
+
+// invalidate drops the cached resources of the cluster, like after creating CRDs.
+func (g *restConfigGetter) invalidate() {
+	g.discovery.Invalidate()
+	if resettable, ok := g.mapper.(meta.ResettableRESTMapper); ok {
+		resettable.Reset()
+	}
+}
+
+func (g *restConfigGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(g.config), nil
+}
+
+func (g *restConfigGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	return g.discovery, nil
+}
+
+func (g *restConfigGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	return g.mapper, nil
+}
+
+func (g *restConfigGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return &restClientConfig{getter: g}
+}
+
+// restClientConfig serves the rest.Config and the namespace of a getter to the clients which
+// expect a kubeconfig.
+type restClientConfig struct {
+	getter *restConfigGetter
+}
+
+func (c *restClientConfig) RawConfig() (clientcmdapi.Config, error) {
+	return clientcmdapi.Config{}, fmt.Errorf("the client config is not read from a kubeconfig")
+}
+
+func (c *restClientConfig) ClientConfig() (*rest.Config, error) {
+	return c.getter.ToRESTConfig()
+}
+
+func (c *restClientConfig) Namespace() (string, bool, error) {
+	if c.getter.namespace == "" {
+		return metav1.NamespaceDefault, false, nil
+	}
+	return c.getter.namespace, true, nil
+}
+
+func (c *restClientConfig) ConfigAccess() clientcmd.ConfigAccess {
+	return clientcmd.NewDefaultClientConfigLoadingRules()
+}
+
+// helmAppsForKubeConfig maps a Secret to the HelmApps reaching their cluster with it.
+func (r *HelmAppReconciler) helmAppsForKubeConfig(ctx context.Context, obj client.Object) []reconcile.Request {
+	helmApps := &operatorv1alpha1.HelmAppList{}
+	if err := r.List(ctx, helmApps, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, helmApp := range helmApps.Items {
+		if helmApp.Spec.GetKubeConfigSecretRef().GetName() == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: helmApp.Namespace, Name: helmApp.Name},
+			})
+		}
+	}
+	return requests
+}
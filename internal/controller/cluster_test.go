@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const remoteKubeConfig = `apiVersion: v1
+kind: Config
+current-context: member
+clusters:
+- name: member
+  cluster:
+    server: https://member.example.com:6443
+contexts:
+- name: member
+  context:
+    cluster: member
+    user: operator
+    namespace: default
+users:
+- name: operator
+  user:
+    token: secret-token
+`
+
+func Test_loadKubeConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    string
+		wantErr string
+	}{
+		{name: "inline token", user: "    token: secret-token\n"},
+		{name: "exec plugin", user: "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: aws\n",
+			wantErr: "exec credential plugins are not allowed"},
+		{name: "token file", user: "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token\n",
+			wantErr: "files are not allowed"},
+		{name: "client certificate file", user: "    client-certificate: /etc/tls/tls.crt\n",
+			wantErr: "files are not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(remoteKubeConfig, "    token: secret-token\n", tt.user, 1)
+			_, err := loadKubeConfig([]byte(data))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("loadKubeConfig() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("loadKubeConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_restConfigGetter(t *testing.T) {
+	kubeConfig, err := loadKubeConfig([]byte(remoteKubeConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	restConfig, err := kubeConfigRESTConfig(kubeConfig)
+	if err != nil {
+		t.Fatalf("kubeConfigRESTConfig() error = %v", err)
+	}
+	if restConfig.Host != "https://member.example.com:6443" || restConfig.BearerToken != "secret-token" {
+		t.Errorf("kubeConfigRESTConfig() = %s with token %q, want the member cluster", restConfig.Host, restConfig.BearerToken)
+	}
+
+	cluster, err := newRESTConfigGetter(restConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	getter := cluster.impersonating(serviceAccountUser("mesh", "deployer")).inNamespace("istio-system")
+	if namespace, overridden, err := getter.ToRawKubeConfigLoader().Namespace(); err != nil || namespace != "istio-system" || !overridden {
+		t.Errorf("Namespace() = %q, %v, %v, want istio-system", namespace, overridden, err)
+	}
+	config, _ := getter.ToRESTConfig()
+	if config.Impersonate.UserName != "system:serviceaccount:mesh:deployer" {
+		t.Errorf("ToRESTConfig() impersonates %q, want the deployer ServiceAccount", config.Impersonate.UserName)
+	}
+	if restConfig.Impersonate.UserName != "" {
+		t.Errorf("impersonating() changed the config of the cluster")
+	}
+	if discovery, _ := getter.ToDiscoveryClient(); discovery != cluster.discovery {
+		t.Errorf("getters of the cluster don't share the discovery client")
+	}
+}
+
+func Test_clusterActionConfigs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{"kubeconfig": []byte("not a kubeconfig")}}
+	secret.Namespace, secret.Name = "istio-system", "member"
+	r := &HelmAppReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(), Scheme: scheme}
+
+	tests := []struct {
+		name           string
+		ref            *operatorv1alpha1.SecretKeyReference
+		serviceAccount string
+		wantErr        string
+	}{
+		{name: "local cluster"},
+		{name: "missing service account", serviceAccount: "deployer",
+			wantErr: "failed to get ServiceAccount deployer"},
+		{name: "missing secret", ref: &operatorv1alpha1.SecretKeyReference{Name: "other"},
+			wantErr: "failed to get kubeconfig Secret other"},
+		{name: "missing key", ref: &operatorv1alpha1.SecretKeyReference{Name: "member"},
+			wantErr: "kubeconfig Secret member has no key value"},
+		{name: "invalid kubeconfig", ref: &operatorv1alpha1.SecretKeyReference{Name: "member", Key: "kubeconfig"},
+			wantErr: "invalid kubeconfig in Secret member"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helmApp := &operatorv1alpha1.HelmApp{Spec: &operatorv1alpha1.HelmAppSpec{
+				KubeConfigSecretRef: tt.ref,
+				ServiceAccountName:  tt.serviceAccount,
+			}}
+			helmApp.Namespace = "istio-system"
+			configs, err := r.clusterActionConfigs(context.Background(), helmApp)
+			if tt.wantErr == "" {
+				if err != nil || configs.client != r.Client || configs.getter != r.cluster {
+					t.Fatalf("clusterActionConfigs() = %v, %v, want the local cluster", configs, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("clusterActionConfigs() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"pluma.io/pluma-opeartor/config"
+	ctllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxConcurrency returns the number of components of the HelmApp reconciled at the same time.
+func (r *HelmAppReconciler) maxConcurrency(helmApp *operatorv1alpha1.HelmApp) int {
+	if n := helmApp.Spec.GetMaxConcurrency(); n > 0 {
+		return int(n)
+	}
+	if r.Config.MaxConcurrentComponents > 0 {
+		return r.Config.MaxConcurrentComponents
+	}
+	return config.DefaultMaxConcurrentComponents
+}
+
+// reconcileComponents reconciles the components sorted by sortComponents. Every component
+// runs as soon as the components it depends on are done, at most maxConcurrency at a time.
+// It returns the status of every component by name and the errors of all components.
+func (r *HelmAppReconciler) reconcileComponents(ctx context.Context, helmApp *operatorv1alpha1.HelmApp,
+	components []*operatorv1alpha1.HelmComponent, configs *actionConfigs) (map[string]*operatorv1alpha1.HelmComponentStatus, error) {
+	cLog := ctllog.FromContext(ctx)
+
+	done := make(map[string]chan struct{}, len(components))
+	for _, component := range components {
+		done[component.Name] = make(chan struct{})
+	}
+
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		mErrs        = &multierror.Error{}
+		statusByName = make(map[string]*operatorv1alpha1.HelmComponentStatus, len(components))
+		workers      = make(chan struct{}, r.maxConcurrency(helmApp))
+	)
+	for _, component := range components {
+		wg.Add(1)
+		go func(component *operatorv1alpha1.HelmComponent) {
+			defer wg.Done()
+			defer close(done[component.Name])
+
+			// Dependencies are sorted before the component, so waiting on them can't deadlock
+			for _, dep := range component.DependsOn {
+				<-done[dep]
+			}
+
+			// A suspended component keeps its release and status until it is resumed
+			if isSuspended(helmApp, component.Name) {
+				cLog.V(1).Info("Component is suspended", "component", component.Name)
+				mu.Lock()
+				statusByName[component.Name] = previousStatus(helmApp, component.Name)
+				mu.Unlock()
+				return
+			}
+
+			mu.Lock()
+			pending := pendingDependencies(component, statusByName)
+			mu.Unlock()
+			if len(pending) > 0 {
+				status := previousStatus(helmApp, component.Name)
+				status.Status = componentStatusWaiting
+				status.Message = fmt.Sprintf("waiting for dependencies: %s", strings.Join(pending, ", "))
+				status.Reason = ""
+				mu.Lock()
+				statusByName[component.Name] = status
+				mu.Unlock()
+				return
+			}
+
+			workers <- struct{}{}
+			componentCtx := ctllog.IntoContext(ctx, cLog.WithValues("component", component.Name))
+			status, err := r.reconcileComponent(componentCtx, helmApp, component, configs)
+			<-workers
+			status.Reason = errorReason(err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			statusByName[component.Name] = status
+			if err != nil {
+				multierror.Append(mErrs, fmt.Errorf("component %s: %w", component.Name, err))
+			}
+		}(component)
+	}
+	wg.Wait()
+
+	return statusByName, mErrs.ErrorOrNil()
+}
+
+// isSuspended tells whether the HelmApp or its component called name is suspended, the release of
+// a suspended component is neither installed, upgraded nor uninstalled.
+func isSuspended(helmApp *operatorv1alpha1.HelmApp, name string) bool {
+	if helmApp.Spec.GetSuspend() {
+		return true
+	}
+	for _, component := range helmApp.Spec.GetComponents() {
+		if component.GetName() == name {
+			return component.GetSuspend()
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+// Condition types, following the kstatus conventions
+const (
+	conditionReady             = "Ready"
+	conditionReconciling       = "Reconciling"
+	conditionStalled           = "Stalled"
+	conditionDegraded          = "Degraded"
+	conditionDependenciesReady = "DependenciesReady"
+	conditionSuspended         = "Suspended"
+)
+
+// Condition statuses
+const (
+	conditionTrue  = "True"
+	conditionFalse = "False"
+)
+
+// Condition reasons
+const (
+	reasonReconciled             = "Reconciled"
+	reasonProgressing            = "Progressing"
+	reasonDependenciesReady      = "DependenciesReady"
+	reasonWaitingForDependencies = "WaitingForDependencies"
+	reasonReleaseFailed          = "ReleaseFailed"
+	reasonResourcesFailed        = "ResourcesFailed"
+	reasonReconcileFailed        = "ReconcileFailed"
+	reasonRetriesExhausted       = "RetriesExhausted"
+	reasonInvalidSpec            = "InvalidSpec"
+	reasonDeleting               = "Deleting"
+	reasonSuspended              = "Suspended"
+	reasonAwaitingApproval       = "AwaitingApproval"
+	reasonTestFailed             = "TestFailed"
+	reasonForbidden              = "Forbidden"
+)
+
+// conditionTypes lists the condition types in the order they are reported
+var conditionTypes = []string{conditionReady, conditionReconciling, conditionStalled, conditionDegraded, conditionDependenciesReady,
+	conditionSuspended}
+
+// healthyStatus is the status of every condition type when everything is fine
+var healthyStatus = map[string]string{
+	conditionReady:             conditionTrue,
+	conditionReconciling:       conditionFalse,
+	conditionStalled:           conditionFalse,
+	conditionDegraded:          conditionFalse,
+	conditionDependenciesReady: conditionTrue,
+	conditionSuspended:         conditionFalse,
+}
+
+func newCondition(conditionType string, status bool, reason, message string, generation int64) *operatorv1alpha1.Condition {
+	condition := &operatorv1alpha1.Condition{
+		Type:               conditionType,
+		Status:             conditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+	if status {
+		condition.Status = conditionTrue
+	}
+	return condition
+}
+
+// findCondition returns the condition of the given type, or nil.
+func findCondition(conditions []*operatorv1alpha1.Condition, conditionType string) *operatorv1alpha1.Condition {
+	for _, c := range conditions {
+		if c.GetType() == conditionType {
+			return c
+		}
+	}
+	return nil
+}
+
+// setConditions returns the new conditions, keeping the last transition time of the previous
+// conditions whose status didn't change.
+func setConditions(previous, conditions []*operatorv1alpha1.Condition) []*operatorv1alpha1.Condition {
+	now := timestamppb.Now()
+	for _, c := range conditions {
+		if old := findCondition(previous, c.Type); old != nil && old.Status == c.Status && old.LastTransitionTime != nil {
+			c.LastTransitionTime = old.LastTransitionTime
+		} else {
+			c.LastTransitionTime = now
+		}
+	}
+	return conditions
+}
+
+// componentConditions computes the conditions of a component from the status reconciled for it.
+// statuses holds the status of every component by name and specErr the error which prevented the
+// components from being reconciled, if any.
+func componentConditions(helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
+	status *operatorv1alpha1.HelmComponentStatus, statuses map[string]*operatorv1alpha1.HelmComponentStatus,
+	specErr error) []*operatorv1alpha1.Condition {
+	generation := helmApp.Generation
+
+	pending := pendingDependencies(component, statuses)
+	dependenciesReady := newCondition(conditionDependenciesReady, true, reasonDependenciesReady, "", generation)
+	if len(pending) > 0 {
+		dependenciesReady = newCondition(conditionDependenciesReady, false, reasonWaitingForDependencies,
+			fmt.Sprintf("waiting for dependencies: %s", strings.Join(pending, ", ")), generation)
+	}
+
+	// Errors which won't go away without changing the spec
+	var stalledReason, stalledMessage string
+	switch {
+	case specErr != nil:
+		stalledReason, stalledMessage = reasonInvalidSpec, specErr.Error()
+	case retriesExhausted(status, component.GetUpgradePolicy(), status.GetFailedConfigHash()):
+		stalledReason, stalledMessage = reasonRetriesExhausted, status.GetMessage()
+	}
+
+	var degradedReason, degradedMessage string
+	health := resourcesHealth(status.GetResources())
+	switch {
+	case status.GetReason() == reasonForbidden:
+		degradedReason, degradedMessage = reasonForbidden, status.GetMessage()
+	case status.GetStatus() == helmrelease.StatusFailed.String():
+		degradedReason, degradedMessage = reasonReleaseFailed, status.GetMessage()
+	case health == healthFailed:
+		degradedReason, degradedMessage = reasonResourcesFailed, resourcesMessage(status.GetResources(), healthFailed)
+	case testFailed(status):
+		degradedReason, degradedMessage = reasonTestFailed, testMessage(status.GetLastTest())
+	case status.GetMessage() != "" && status.GetStatus() != componentStatusWaiting:
+		degradedReason, degradedMessage = reasonReconcileFailed, status.GetMessage()
+	}
+
+	var ready, reconciling *operatorv1alpha1.Condition
+	switch {
+	case stalledReason != "":
+		ready = newCondition(conditionReady, false, stalledReason, stalledMessage, generation)
+		reconciling = newCondition(conditionReconciling, false, stalledReason, stalledMessage, generation)
+	case degradedReason != "":
+		ready = newCondition(conditionReady, false, degradedReason, degradedMessage, generation)
+		reconciling = newCondition(conditionReconciling, false, degradedReason, degradedMessage, generation)
+	case status.GetPlan() != nil:
+		message := fmt.Sprintf("%s plan %s is waiting for approval", status.GetPlan().GetAction(), status.GetPlan().GetHash())
+		ready = newCondition(conditionReady, false, reasonAwaitingApproval, message, generation)
+		reconciling = newCondition(conditionReconciling, false, reasonAwaitingApproval, message, generation)
+	case isComponentReady(status):
+		ready = newCondition(conditionReady, true, reasonReconciled, "", generation)
+		reconciling = newCondition(conditionReconciling, false, reasonReconciled, "", generation)
+	case len(pending) > 0:
+		ready = newCondition(conditionReady, false, dependenciesReady.Reason, dependenciesReady.Message, generation)
+		reconciling = newCondition(conditionReconciling, true, dependenciesReady.Reason, dependenciesReady.Message, generation)
+	default:
+		message := resourcesMessage(status.GetResources(), healthInProgress)
+		if message == "" {
+			message = fmt.Sprintf("release is %s", status.GetStatus())
+		}
+		ready = newCondition(conditionReady, false, reasonProgressing, message, generation)
+		reconciling = newCondition(conditionReconciling, true, reasonProgressing, message, generation)
+	}
+
+	stalled := newCondition(conditionStalled, stalledReason != "", reasonReconciled, "", generation)
+	if stalledReason != "" {
+		stalled.Reason, stalled.Message = stalledReason, stalledMessage
+	}
+	degraded := newCondition(conditionDegraded, degradedReason != "", reasonReconciled, "", generation)
+	if degradedReason != "" {
+		degraded.Reason, degraded.Message = degradedReason, degradedMessage
+	}
+
+	// A suspended component keeps its release as is, nothing is in progress
+	suspended := newCondition(conditionSuspended, false, reasonReconciled, "", generation)
+	if message := suspendedMessage(helmApp, component); message != "" {
+		suspended = newCondition(conditionSuspended, true, reasonSuspended, message, generation)
+		if reconciling.Status == conditionTrue {
+			ready = newCondition(conditionReady, false, reasonSuspended, message, generation)
+		}
+		reconciling = newCondition(conditionReconciling, false, reasonSuspended, message, generation)
+	}
+
+	return setConditions(previousStatus(helmApp, component.Name).GetConditions(), []*operatorv1alpha1.Condition{
+		ready, reconciling, stalled, degraded, dependenciesReady, suspended,
+	})
+}
+
+// suspendedMessage tells why the component is suspended, or returns an empty string if it isn't.
+func suspendedMessage(helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent) string {
+	switch {
+	case helmApp.Spec.GetSuspend():
+		return "reconciliation of the HelmApp is suspended"
+	case component.GetSuspend():
+		return "reconciliation of the component is suspended"
+	}
+	return ""
+}
+
+// resourcesMessage describes the first resource with the given health.
+func resourcesMessage(resources []*operatorv1alpha1.HelmResourceStatus, health string) string {
+	for _, res := range resources {
+		if res.GetHealth() == health || health == healthInProgress && res.GetHealth() == healthNotFound {
+			if res.GetMessage() == "" {
+				return fmt.Sprintf("%s %s is %s", res.GetKind(), res.GetName(), res.GetHealth())
+			}
+			return fmt.Sprintf("%s %s: %s", res.GetKind(), res.GetName(), res.GetMessage())
+		}
+	}
+	return ""
+}
+
+// appConditions aggregates the conditions of the components. A condition which isn't healthy in
+// a component is reported with the reason and message of the first such component.
+func appConditions(helmApp *operatorv1alpha1.HelmApp, components []*operatorv1alpha1.HelmComponentStatus) []*operatorv1alpha1.Condition {
+	var conditions []*operatorv1alpha1.Condition
+	for _, conditionType := range conditionTypes {
+		healthy := healthyStatus[conditionType] == conditionTrue
+		reason := reasonReconciled
+		if conditionType == conditionDependenciesReady {
+			reason = reasonDependenciesReady
+		}
+		condition := newCondition(conditionType, healthy, reason, "", helmApp.Generation)
+		for _, component := range components {
+			c := findCondition(component.GetConditions(), conditionType)
+			if c == nil || c.Status == healthyStatus[conditionType] {
+				continue
+			}
+			condition.Status = c.Status
+			condition.Reason = c.Reason
+			condition.Message = fmt.Sprintf("component %s: %s", component.GetName(), c.Message)
+			break
+		}
+		conditions = append(conditions, condition)
+	}
+	return setConditions(helmApp.Status.GetConditions(), conditions)
+}
+
+// deletingConditions reports the HelmApp as reconciling while its components are uninstalled.
+func deletingConditions(helmApp *operatorv1alpha1.HelmApp) []*operatorv1alpha1.Condition {
+	message := "uninstalling components"
+	conditions := []*operatorv1alpha1.Condition{
+		newCondition(conditionReady, false, reasonDeleting, message, helmApp.Generation),
+		newCondition(conditionReconciling, true, reasonDeleting, message, helmApp.Generation),
+	}
+	for _, c := range helmApp.Status.GetConditions() {
+		if c.Type != conditionReady && c.Type != conditionReconciling {
+			conditions = append(conditions, c)
+		}
+	}
+	return setConditions(helmApp.Status.GetConditions(), conditions)
+}
+
+// testFailed tells whether the tests of the current revision of the component failed.
+func testFailed(status *operatorv1alpha1.HelmComponentStatus) bool {
+	test := status.GetLastTest()
+	return test.GetResult() == testResultFailed && status.GetVersion() == strconv.Itoa(int(test.GetRevision()))
+}
+
+// errorReason returns the reason recorded with the error of a component: Forbidden for a request
+// denied by RBAC, like the requests of a HelmApp acting as a ServiceAccount without enough
+// permissions. Helm joins the errors of some actions into a message, they are recognized by the
+// text of the API error.
+func errorReason(err error) string {
+	if apierrors.IsForbidden(err) || (err != nil && strings.Contains(err.Error(), " is forbidden: ")) {
+		return reasonForbidden
+	}
+	return ""
+}
+
+// testMessage describes the first failed test.
+func testMessage(test *operatorv1alpha1.HelmTestStatus) string {
+	for _, result := range test.GetTests() {
+		if result.GetPhase() == helmrelease.HookPhaseFailed.String() {
+			return fmt.Sprintf("test %s of revision %d failed", result.GetName(), test.GetRevision())
+		}
+	}
+	return fmt.Sprintf("tests of revision %d failed", test.GetRevision())
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+// conditionSummary returns type=status/reason of every condition.
+func conditionSummary(conditions []*operatorv1alpha1.Condition) []string {
+	var summary []string
+	for _, c := range conditions {
+		summary = append(summary, c.Type+"="+c.Status+"/"+c.Reason)
+	}
+	return summary
+}
+
+func Test_componentConditions(t *testing.T) {
+	deployed := &operatorv1alpha1.HelmComponentStatus{Name: "base", Status: "deployed"}
+	// Helm reports the errors of an upgrade as text, the reason is read from it
+	forbidden := `failed to upgrade release: deployments.apps "istiod" is forbidden: ` +
+		`User "system:serviceaccount:mesh:deployer" cannot patch resource "deployments"`
+	tests := []struct {
+		name      string
+		component *operatorv1alpha1.HelmComponent
+		status    *operatorv1alpha1.HelmComponentStatus
+		specErr   error
+		want      []string
+	}{
+		{
+			name:      "ready",
+			component: newComponent("istiod", "base"),
+			status:    &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "deployed"},
+			want: []string{
+				"Ready=True/Reconciled", "Reconciling=False/Reconciled", "Stalled=False/Reconciled",
+				"Degraded=False/Reconciled", "DependenciesReady=True/DependenciesReady", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "waiting for dependencies",
+			component: newComponent("gateway", "istiod"),
+			status:    &operatorv1alpha1.HelmComponentStatus{Name: "gateway", Status: componentStatusWaiting, Message: "waiting for dependencies: istiod"},
+			want: []string{
+				"Ready=False/WaitingForDependencies", "Reconciling=True/WaitingForDependencies", "Stalled=False/Reconciled",
+				"Degraded=False/Reconciled", "DependenciesReady=False/WaitingForDependencies", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "resources rolling out",
+			component: newComponent("istiod"),
+			status: &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "deployed", Resources: []*operatorv1alpha1.HelmResourceStatus{
+				{Kind: "Deployment", Name: "istiod", Health: healthInProgress, Message: "0 of 1 replicas available"},
+			}},
+			want: []string{
+				"Ready=False/Progressing", "Reconciling=True/Progressing", "Stalled=False/Reconciled",
+				"Degraded=False/Reconciled", "DependenciesReady=True/DependenciesReady", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "release failed",
+			component: newComponent("istiod"),
+			status:    &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "failed", Message: "failed to upgrade release"},
+			want: []string{
+				"Ready=False/ReleaseFailed", "Reconciling=False/ReleaseFailed", "Stalled=False/Reconciled",
+				"Degraded=True/ReleaseFailed", "DependenciesReady=True/DependenciesReady", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "forbidden",
+			component: newComponent("istiod"),
+			status: &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "failed",
+				Reason: errorReason(errors.New(forbidden)), Message: forbidden},
+			want: []string{
+				"Ready=False/Forbidden", "Reconciling=False/Forbidden", "Stalled=False/Reconciled",
+				"Degraded=True/Forbidden", "DependenciesReady=True/DependenciesReady", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name: "retries exhausted",
+			component: &operatorv1alpha1.HelmComponent{
+				Name:          "istiod",
+				UpgradePolicy: &operatorv1alpha1.UpgradePolicy{MaxRetries: 2},
+			},
+			status: &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "failed", Failures: 2, FailedConfigHash: "a"},
+			want: []string{
+				"Ready=False/RetriesExhausted", "Reconciling=False/RetriesExhausted", "Stalled=True/RetriesExhausted",
+				"Degraded=True/ReleaseFailed", "DependenciesReady=True/DependenciesReady", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "invalid spec",
+			component: newComponent("istiod", "istiod"),
+			status:    &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "failed"},
+			specErr:   errors.New("dependency cycle detected: istiod -> istiod"),
+			want: []string{
+				"Ready=False/InvalidSpec", "Reconciling=False/InvalidSpec", "Stalled=True/InvalidSpec",
+				"Degraded=True/ReleaseFailed", "DependenciesReady=False/WaitingForDependencies", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "tests failed",
+			component: newComponent("istiod"),
+			status: &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "deployed", Version: "2", LastTest: &operatorv1alpha1.HelmTestStatus{
+				Revision: 2, Result: testResultFailed,
+			}},
+			want: []string{
+				"Ready=False/TestFailed", "Reconciling=False/TestFailed", "Stalled=False/Reconciled",
+				"Degraded=True/TestFailed", "DependenciesReady=True/DependenciesReady", "Suspended=False/Reconciled",
+			},
+		},
+		{
+			name:      "suspended component",
+			component: &operatorv1alpha1.HelmComponent{Name: "istiod", Suspend: true},
+			status:    &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "deployed"},
+			want: []string{
+				"Ready=True/Reconciled", "Reconciling=False/Suspended", "Stalled=False/Reconciled",
+				"Degraded=False/Reconciled", "DependenciesReady=True/DependenciesReady", "Suspended=True/Suspended",
+			},
+		},
+		{
+			name:      "suspended before install",
+			component: &operatorv1alpha1.HelmComponent{Name: "istiod", Suspend: true},
+			status:    &operatorv1alpha1.HelmComponentStatus{Name: "istiod"},
+			want: []string{
+				"Ready=False/Suspended", "Reconciling=False/Suspended", "Stalled=False/Reconciled",
+				"Degraded=False/Reconciled", "DependenciesReady=True/DependenciesReady", "Suspended=True/Suspended",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helmApp := &operatorv1alpha1.HelmApp{}
+			statuses := map[string]*operatorv1alpha1.HelmComponentStatus{"base": deployed, tt.status.Name: tt.status}
+			got := componentConditions(helmApp, tt.component, tt.status, statuses, tt.specErr)
+			if diff := cmp.Diff(tt.want, conditionSummary(got)); diff != "" {
+				t.Errorf("componentConditions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_setConditions(t *testing.T) {
+	before := timestamppb.New(time.Now().Add(-time.Minute))
+	previous := []*operatorv1alpha1.Condition{
+		{Type: conditionReady, Status: conditionTrue, LastTransitionTime: before},
+		{Type: conditionDegraded, Status: conditionFalse, LastTransitionTime: before},
+	}
+	got := setConditions(previous, []*operatorv1alpha1.Condition{
+		newCondition(conditionReady, true, reasonReconciled, "", 1),
+		newCondition(conditionDegraded, true, reasonReleaseFailed, "", 1),
+	})
+	if got[0].LastTransitionTime != before {
+		t.Errorf("setConditions() changed the transition time of an unchanged condition")
+	}
+	if got[1].LastTransitionTime == before || got[1].LastTransitionTime == nil {
+		t.Errorf("setConditions() kept the transition time of a changed condition")
+	}
+}
+
+func Test_errorReason(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "istiod",
+		errors.New(`User "system:serviceaccount:mesh:deployer" cannot patch resource "deployments"`))
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "no error"},
+		{name: "forbidden", err: multierror.Append(&multierror.Error{}, errors.New("drifted"),
+			fmt.Errorf("failed to upgrade release: %w", fmt.Errorf("release istiod failed: %w", forbidden))), want: reasonForbidden},
+		{name: "forbidden message only", err: errors.New(forbidden.Error()), want: reasonForbidden},
+		{
+			// kube.Client.Update joins the errors of every resource into a message
+			name: "helm update",
+			err: fmt.Errorf("failed to upgrade release: %w", errors.New(strings.Join([]string{
+				fmt.Sprintf("cannot patch %q with kind Deployment: %v", "istiod", forbidden),
+				`cannot patch "istiod" with kind Service: services "istiod" not found`,
+			}, " && "))),
+			want: reasonForbidden,
+		},
+		{name: "forbidden word", err: errors.New(`the deployment "forbidden" is not ready`)},
+		{name: "not found", err: apierrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, "deployer")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorReason(tt.err); got != tt.want {
+				t.Errorf("errorReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Policies of the CRDs of a chart
+const (
+	crdPolicySkip          = "Skip"
+	crdPolicyCreate        = "Create"
+	crdPolicyCreateReplace = "CreateReplace"
+)
+
+// crdFieldManager owns the fields of the CRDs applied by the operator
+const crdFieldManager = "pluma-operator"
+
+// crdEstablishedTimeout is how long applied CRDs have to be established
+const crdEstablishedTimeout = time.Minute
+
+// crdPollInterval is how often the CRDs are checked while waiting for them
+const crdPollInterval = 2 * time.Second
+
+// chartCRDs returns the CRDs of the crds/ directories of the chart and its dependencies.
+func chartCRDs(chart *helmchart.Chart) ([]*unstructured.Unstructured, error) {
+	var crds []*unstructured.Unstructured
+	for _, crd := range chart.CRDObjects() {
+		manifests := releaseutil.SplitManifests(string(crd.File.Data))
+		keys := make([]string, 0, len(manifests))
+		for key := range manifests {
+			keys = append(keys, key)
+		}
+		sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+		for _, key := range keys {
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(manifests[key]), &obj.Object); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", crd.Filename, err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if obj.GetKind() != "CustomResourceDefinition" {
+				return nil, fmt.Errorf("%s holds a %s, only CustomResourceDefinitions are allowed", crd.Filename, obj.GetKind())
+			}
+			crds = append(crds, obj)
+		}
+	}
+	return crds, nil
+}
+
+// applyCRDs creates the CRDs of the chart, or applies them over the existing ones with the
+// CreateReplace policy, and waits for them to be established. It returns the status of the CRDs.
+func applyCRDs(ctx context.Context, kubeClient client.Client, chart *helmchart.Chart, policy string) ([]*operatorv1alpha1.HelmCRDStatus, error) {
+	crds, err := chartCRDs(chart)
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range crds {
+		switch policy {
+		case crdPolicyCreate:
+			if err := kubeClient.Create(ctx, crd.DeepCopy()); err != nil && !errors2.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("failed to create CRD %s: %w", crd.GetName(), err)
+			}
+		case crdPolicyCreateReplace:
+			if err := kubeClient.Patch(ctx, crd.DeepCopy(), client.Apply, client.FieldOwner(crdFieldManager), client.ForceOwnership); err != nil {
+				return nil, fmt.Errorf("failed to apply CRD %s: %w", crd.GetName(), err)
+			}
+		}
+	}
+
+	statuses := make([]*operatorv1alpha1.HelmCRDStatus, 0, len(crds))
+	for _, crd := range crds {
+		status, err := waitCRDEstablished(ctx, kubeClient, crd)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// waitCRDEstablished waits for the CRD to be established and returns its versions.
+func waitCRDEstablished(ctx context.Context, kubeClient client.Client, crd *unstructured.Unstructured) (*operatorv1alpha1.HelmCRDStatus, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(crd.GroupVersionKind())
+	err := wait.PollUntilContextTimeout(ctx, crdPollInterval, crdEstablishedTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := kubeClient.Get(ctx, client.ObjectKeyFromObject(crd), live); err != nil {
+			if errors2.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return crdEstablished(live), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CRD %s is not established: %w", crd.GetName(), err)
+	}
+	return crdStatus(live), nil
+}
+
+// crdEstablished tells whether the Established condition of the CRD is true.
+func crdEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, _ := c.(map[string]any)
+		if condition["type"] == "Established" && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// crdStatus returns the name and the versions of the CRD.
+func crdStatus(crd *unstructured.Unstructured) *operatorv1alpha1.HelmCRDStatus {
+	status := &operatorv1alpha1.HelmCRDStatus{Name: crd.GetName()}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, _ := v.(map[string]any)
+		name, _ := version["name"].(string)
+		if served, _ := version["served"].(bool); served {
+			status.Versions = append(status.Versions, name)
+		}
+		if storage, _ := version["storage"].(bool); storage {
+			status.StorageVersion = name
+		}
+	}
+	return status
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const gatewaysCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+spec:
+  group: networking.istio.io
+  names:
+    kind: Gateway
+    plural: gateways
+  scope: Namespaced
+  versions:
+  - name: v1alpha3
+    served: true
+    storage: false
+  - name: v1beta1
+    served: true
+    storage: true
+`
+
+const telemetriesCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: telemetries.telemetry.istio.io
+spec:
+  group: telemetry.istio.io
+  names:
+    kind: Telemetry
+    plural: telemetries
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+`
+
+func Test_applyCRDs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	established := func(name string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha3", Served: true, Storage: true},
+			}},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+			}},
+		}
+	}
+	chart := &helmchart.Chart{
+		Metadata: &helmchart.Metadata{Name: "base"},
+		Files: []*helmchart.File{
+			{Name: "crds/crd-all.gen.yaml", Data: []byte(gatewaysCRD + "---\n" + telemetriesCRD)},
+			{Name: "files/profile.yaml", Data: []byte("kind: ConfigMap\n")},
+		},
+	}
+
+	crds, err := chartCRDs(chart)
+	if err != nil {
+		t.Fatalf("chartCRDs() error = %v", err)
+	}
+	if len(crds) != 2 || crds[0].GetName() != "gateways.networking.istio.io" || crds[1].GetName() != "telemetries.telemetry.istio.io" {
+		t.Fatalf("chartCRDs() = %v, want the gateways and telemetries CRDs", crds)
+	}
+
+	// The existing CRDs are left as they are with the Create policy
+	r := &HelmAppReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		established("gateways.networking.istio.io"),
+		established("telemetries.telemetry.istio.io"),
+	).Build()}
+	got, err := applyCRDs(context.Background(), r.Client, chart, crdPolicyCreate)
+	if err != nil {
+		t.Fatalf("applyCRDs() error = %v", err)
+	}
+	want := []*operatorv1alpha1.HelmCRDStatus{
+		{Name: "gateways.networking.istio.io", Versions: []string{"v1alpha3"}, StorageVersion: "v1alpha3"},
+		{Name: "telemetries.telemetry.istio.io", Versions: []string{"v1alpha3"}, StorageVersion: "v1alpha3"},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("applyCRDs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_crdStatus(t *testing.T) {
+	crds, err := chartCRDs(&helmchart.Chart{
+		Metadata: &helmchart.Metadata{Name: "base"},
+		Files:    []*helmchart.File{{Name: "crds/gateways.yaml", Data: []byte(gatewaysCRD)}},
+	})
+	if err != nil || len(crds) != 1 {
+		t.Fatalf("chartCRDs() = %v, %v", crds, err)
+	}
+	want := &operatorv1alpha1.HelmCRDStatus{
+		Name:           "gateways.networking.istio.io",
+		Versions:       []string{"v1alpha3", "v1beta1"},
+		StorageVersion: "v1beta1",
+	}
+	if diff := cmp.Diff(want, crdStatus(crds[0]), protocmp.Transform()); diff != "" {
+		t.Errorf("crdStatus() mismatch (-want +got):\n%s", diff)
+	}
+	if crdEstablished(crds[0]) {
+		t.Errorf("crdEstablished() = true for a CRD without status")
+	}
+}
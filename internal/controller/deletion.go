@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	helmaction "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/kube"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// Deletion policies of the releases
+const (
+	deletionPolicyDelete   = "Delete"
+	deletionPolicyOrphan   = "Orphan"
+	deletionPolicyKeepCRDs = "KeepCRDs"
+	deletionPolicyKeepPVCs = "KeepPVCs"
+)
+
+// deletionPolicy returns the deletion policy of the component called name, the component policy
+// wins over the HelmApp policy. Components removed from the spec get the HelmApp policy.
+func deletionPolicy(helmApp *operatorv1alpha1.HelmApp, name string) string {
+	for _, component := range helmApp.Spec.GetComponents() {
+		if component.GetName() == name && component.GetDeletionPolicy() != "" {
+			return component.GetDeletionPolicy()
+		}
+	}
+	if policy := helmApp.Spec.GetDeletionPolicy(); policy != "" {
+		return policy
+	}
+	return deletionPolicyDelete
+}
+
+// keptKinds returns the kinds of the resources the deletion policy keeps on uninstall.
+func keptKinds(policy string) map[string]bool {
+	switch policy {
+	case deletionPolicyKeepCRDs:
+		return map[string]bool{"CustomResourceDefinition": true}
+	case deletionPolicyKeepPVCs:
+		return map[string]bool{"PersistentVolumeClaim": true}
+	}
+	return nil
+}
+
+// keptByPolicy tells whether the deletion policy keeps the resources of the kind on uninstall, the
+// Orphan policy keeps all of them.
+func keptByPolicy(policy, kind string) bool {
+	return policy == deletionPolicyOrphan || keptKinds(policy)[kind]
+}
+
+// hasKeepPolicy tells whether the annotations make helm keep the resource on uninstall.
+func hasKeepPolicy(annotations map[string]string) bool {
+	return strings.ToLower(strings.TrimSpace(annotations[kube.ResourcePolicyAnno])) == kube.KeepPolicy
+}
+
+// keepResources sets the keep resource policy on the resources of the given kinds in the manifest
+// of the release and stores it, so uninstalling the release leaves them in place. It returns the
+// number of resources marked.
+func keepResources(helmCfg *helmaction.Configuration, release *helmrelease.Release, kinds map[string]bool) (int, error) {
+	manifest, marked, err := markKept(release.Manifest, kinds)
+	if err != nil || marked == 0 {
+		return 0, err
+	}
+	release.Manifest = manifest
+	if err := helmCfg.Releases.Update(release); err != nil {
+		return 0, fmt.Errorf("failed to update release manifest: %w", err)
+	}
+	return marked, nil
+}
+
+// markKept adds the keep resource policy annotation to the resources of the given kinds.
+func markKept(manifest string, kinds map[string]bool) (string, int, error) {
+	manifests := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(manifests))
+	for key := range manifests {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	marked := 0
+	docs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		doc := manifests[key]
+		obj := map[string]any{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return "", 0, fmt.Errorf("failed to parse release manifest: %w", err)
+		}
+		kind, _ := obj["kind"].(string)
+		metadata, _ := obj["metadata"].(map[string]any)
+		if !kinds[kind] || metadata == nil {
+			docs = append(docs, doc)
+			continue
+		}
+		annotations, _ := metadata["annotations"].(map[string]any)
+		if annotations == nil {
+			annotations = map[string]any{}
+		}
+		annotations[kube.ResourcePolicyAnno] = kube.KeepPolicy
+		metadata["annotations"] = annotations
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to render release manifest: %w", err)
+		}
+		docs = append(docs, strings.TrimSpace(string(data)))
+		marked++
+	}
+	return "---\n" + strings.Join(docs, "\n---\n") + "\n", marked, nil
+}
+
+// keptResources returns kind/name of the resources of the manifest helm keeps on uninstall.
+func keptResources(manifest string) []string {
+	var kept []string
+	for _, res := range manifestResources(manifest) {
+		if res.keep {
+			kept = append(kept, res.change.Kind+"/"+res.change.Name)
+		}
+	}
+	sort.Strings(kept)
+	return kept
+}
+
+// orphanRelease deletes every revision of the release from the helm storage, leaving its
+// resources in the cluster.
+func orphanRelease(helmCfg *helmaction.Configuration, name string) error {
+	history, err := helmCfg.Releases.History(name)
+	if err != nil {
+		return fmt.Errorf("failed to get release history: %w", err)
+	}
+	for _, rel := range history {
+		if _, err := helmCfg.Releases.Delete(rel.Name, rel.Version); err != nil {
+			return fmt.Errorf("failed to delete revision %d: %w", rel.Version, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	helmaction "helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+const baseManifest = `---
+# Source: base/templates/crds.yaml
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+---
+# Source: base/templates/serviceaccount.yaml
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istio-reader-service-account
+---
+# Source: base/templates/pvc.yaml
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  annotations:
+    helm.sh/resource-policy: keep
+`
+
+func Test_deletionPolicy(t *testing.T) {
+	helmApp := &operatorv1alpha1.HelmApp{Spec: &operatorv1alpha1.HelmAppSpec{
+		DeletionPolicy: deletionPolicyKeepCRDs,
+		Components: []*operatorv1alpha1.HelmComponent{
+			{Name: "base"},
+			{Name: "istiod", DeletionPolicy: deletionPolicyOrphan},
+		},
+	}}
+	tests := []struct {
+		name    string
+		helmApp *operatorv1alpha1.HelmApp
+		want    string
+	}{
+		{name: "base", helmApp: helmApp, want: deletionPolicyKeepCRDs},
+		{name: "istiod", helmApp: helmApp, want: deletionPolicyOrphan},
+		{name: "removed", helmApp: helmApp, want: deletionPolicyKeepCRDs},
+		{name: "base", helmApp: &operatorv1alpha1.HelmApp{}, want: deletionPolicyDelete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deletionPolicy(tt.helmApp, tt.name); got != tt.want {
+				t.Errorf("deletionPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_keptByPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		kind   string
+		want   bool
+	}{
+		{policy: deletionPolicyDelete, kind: "CustomResourceDefinition"},
+		{policy: deletionPolicyKeepCRDs, kind: "CustomResourceDefinition", want: true},
+		{policy: deletionPolicyKeepCRDs, kind: "PersistentVolumeClaim"},
+		{policy: deletionPolicyKeepPVCs, kind: "PersistentVolumeClaim", want: true},
+		{policy: deletionPolicyOrphan, kind: "Deployment", want: true},
+		{policy: deletionPolicyOrphan, kind: "CustomResourceDefinition", want: true},
+	}
+	for _, tt := range tests {
+		if got := keptByPolicy(tt.policy, tt.kind); got != tt.want {
+			t.Errorf("keptByPolicy(%s, %s) = %v, want %v", tt.policy, tt.kind, got, tt.want)
+		}
+	}
+}
+
+func Test_keepResources(t *testing.T) {
+	helmCfg := &helmaction.Configuration{
+		Releases:   storage.Init(driver.NewMemory()),
+		KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+		Log:        func(string, ...any) {},
+	}
+	release := &helmrelease.Release{
+		Name:     "base",
+		Version:  1,
+		Info:     &helmrelease.Info{Status: helmrelease.StatusDeployed},
+		Manifest: baseManifest,
+	}
+	if err := helmCfg.Releases.Create(release); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"PersistentVolumeClaim/data"}, keptResources(release.Manifest)); diff != "" {
+		t.Errorf("keptResources() mismatch (-want +got):\n%s", diff)
+	}
+
+	marked, err := keepResources(helmCfg, release, keptKinds(deletionPolicyKeepCRDs))
+	if err != nil {
+		t.Fatalf("keepResources() error = %v", err)
+	}
+	if marked != 1 {
+		t.Errorf("keepResources() marked %d resources, want 1", marked)
+	}
+	stored, err := helmCfg.Releases.Last("base")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"CustomResourceDefinition/gateways.networking.istio.io", "PersistentVolumeClaim/data"}
+	if diff := cmp.Diff(want, keptResources(stored.Manifest)); diff != "" {
+		t.Errorf("keptResources() of the stored release mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(manifestResources(stored.Manifest)); got != 3 {
+		t.Errorf("stored manifest has %d resources, want 3", got)
+	}
+}
+
+func Test_orphanRelease(t *testing.T) {
+	helmCfg := &helmaction.Configuration{
+		Releases:   storage.Init(driver.NewMemory()),
+		KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+		Log:        func(string, ...any) {},
+	}
+	for version := 1; version <= 2; version++ {
+		rel := &helmrelease.Release{Name: "istiod", Version: version, Info: &helmrelease.Info{Status: helmrelease.StatusDeployed}}
+		if err := helmCfg.Releases.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := orphanRelease(helmCfg, "istiod"); err != nil {
+		t.Fatalf("orphanRelease() error = %v", err)
+	}
+	if history, _ := helmCfg.Releases.History("istiod"); len(history) != 0 {
+		t.Errorf("orphanRelease() left %d revisions", len(history))
+	}
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+// componentStatusWaiting is the status of a component whose dependencies are not ready yet
+const componentStatusWaiting = "waiting"
+
+// sortComponents returns the components ordered so that every component comes after the
+// components it depends on, independent components keep their order in the spec.
+// It fails when a component depends on an unknown component or when the dependencies form a cycle.
+func sortComponents(components []*operatorv1alpha1.HelmComponent) ([]*operatorv1alpha1.HelmComponent, error) {
+	byName := make(map[string]*operatorv1alpha1.HelmComponent, len(components))
+	for _, component := range components {
+		byName[component.GetName()] = component
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(components))
+	sorted := make([]*operatorv1alpha1.HelmComponent, 0, len(components))
+	var path []string
+
+	var visit func(component *operatorv1alpha1.HelmComponent) error
+	visit = func(component *operatorv1alpha1.HelmComponent) error {
+		name := component.GetName()
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			// cut the path down to the cycle
+			for i, n := range path {
+				if n == name {
+					return fmt.Errorf("dependency cycle detected: %s -> %s", strings.Join(path[i:], " -> "), name)
+				}
+			}
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range component.GetDependsOn() {
+			depComponent, ok := byName[dep]
+			if !ok {
+				return fmt.Errorf("component %s depends on unknown component %s", name, dep)
+			}
+			if err := visit(depComponent); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		sorted = append(sorted, component)
+		return nil
+	}
+
+	for _, component := range components {
+		if err := visit(component); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// isComponentReady reports whether dependents of a component may be reconciled,
+// the release must be deployed and all its resources healthy.
+func isComponentReady(status *operatorv1alpha1.HelmComponentStatus) bool {
+	return status.GetStatus() == helmrelease.StatusDeployed.String() &&
+		resourcesHealth(status.GetResources()) == healthCurrent
+}
+
+// pendingDependencies returns the dependencies of the component which are not ready in statuses.
+func pendingDependencies(component *operatorv1alpha1.HelmComponent, statuses map[string]*operatorv1alpha1.HelmComponentStatus) []string {
+	var pending []string
+	for _, dep := range component.GetDependsOn() {
+		if !isComponentReady(statuses[dep]) {
+			pending = append(pending, dep)
+		}
+	}
+	return pending
+}
+
+// uninstallOrder returns the indexes of the component statuses in the order they are uninstalled:
+// components which are no longer in the spec first, then the spec components in reverse
+// dependency order. It falls back to the reverse status order when the dependencies are invalid.
+func uninstallOrder(helmApp *operatorv1alpha1.HelmApp) []int {
+	statuses := helmApp.Status.GetComponents()
+	order := make([]int, 0, len(statuses))
+
+	sorted, err := sortComponents(helmApp.Spec.GetComponents())
+	if err != nil {
+		for i := len(statuses) - 1; i >= 0; i-- {
+			order = append(order, i)
+		}
+		return order
+	}
+
+	index := make(map[string]int, len(statuses))
+	for i, status := range statuses {
+		index[status.GetName()] = i
+	}
+	inSpec := make(map[string]bool, len(sorted))
+	for _, component := range sorted {
+		inSpec[component.GetName()] = true
+	}
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if !inSpec[statuses[i].GetName()] {
+			order = append(order, i)
+		}
+	}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if idx, ok := index[sorted[i].GetName()]; ok {
+			order = append(order, idx)
+		}
+	}
+	return order
+}
+
+// installedDependents returns the spec components depending on name which are still installed.
+func installedDependents(helmApp *operatorv1alpha1.HelmApp, name string, installed map[string]bool) []string {
+	var dependents []string
+	for _, component := range helmApp.Spec.GetComponents() {
+		if !installed[component.GetName()] {
+			continue
+		}
+		for _, dep := range component.GetDependsOn() {
+			if dep == name {
+				dependents = append(dependents, component.GetName())
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// previousStatus returns a copy of the last recorded status of the component, or an empty status.
+func previousStatus(helmApp *operatorv1alpha1.HelmApp, name string) *operatorv1alpha1.HelmComponentStatus {
+	for _, status := range helmApp.Status.GetComponents() {
+		if status.GetName() == name {
+			return status.DeepCopy()
+		}
+	}
+	return &operatorv1alpha1.HelmComponentStatus{Name: name}
+}
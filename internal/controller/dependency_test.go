@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func newComponent(name string, dependsOn ...string) *operatorv1alpha1.HelmComponent {
+	return &operatorv1alpha1.HelmComponent{Name: name, DependsOn: dependsOn}
+}
+
+func Test_sortComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []*operatorv1alpha1.HelmComponent
+		want       []string
+		wantErr    string
+	}{
+		{
+			name:       "no dependencies keep spec order",
+			components: []*operatorv1alpha1.HelmComponent{newComponent("base"), newComponent("istiod"), newComponent("gateway")},
+			want:       []string{"base", "istiod", "gateway"},
+		},
+		{
+			name: "istio chain",
+			components: []*operatorv1alpha1.HelmComponent{
+				newComponent("gateway", "istiod"),
+				newComponent("istiod", "base"),
+				newComponent("base"),
+			},
+			want: []string{"base", "istiod", "gateway"},
+		},
+		{
+			name: "diamond",
+			components: []*operatorv1alpha1.HelmComponent{
+				newComponent("app", "cni", "istiod"),
+				newComponent("cni", "base"),
+				newComponent("istiod", "base"),
+				newComponent("base"),
+			},
+			want: []string{"base", "cni", "istiod", "app"},
+		},
+		{
+			name: "cycle",
+			components: []*operatorv1alpha1.HelmComponent{
+				newComponent("base"),
+				newComponent("istiod", "base", "gateway"),
+				newComponent("gateway", "istiod"),
+			},
+			wantErr: "dependency cycle detected: istiod -> gateway -> istiod",
+		},
+		{
+			name:       "self dependency",
+			components: []*operatorv1alpha1.HelmComponent{newComponent("base", "base")},
+			wantErr:    "dependency cycle detected: base -> base",
+		},
+		{
+			name:       "unknown dependency",
+			components: []*operatorv1alpha1.HelmComponent{newComponent("istiod", "base")},
+			wantErr:    "component istiod depends on unknown component base",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sortComponents(tt.components)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("sortComponents() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sortComponents() unexpected error = %v", err)
+			}
+			var names []string
+			for _, c := range got {
+				names = append(names, c.Name)
+			}
+			if diff := cmp.Diff(tt.want, names); diff != "" {
+				t.Errorf("sortComponents() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_uninstallOrder(t *testing.T) {
+	helmApp := &operatorv1alpha1.HelmApp{
+		Spec: &operatorv1alpha1.HelmAppSpec{
+			Components: []*operatorv1alpha1.HelmComponent{
+				newComponent("gateway", "istiod"),
+				newComponent("istiod", "base"),
+				newComponent("base"),
+			},
+		},
+		Status: &operatorv1alpha1.HelmAppStatus{
+			Components: []*operatorv1alpha1.HelmComponentStatus{
+				{Name: "gateway"},
+				{Name: "istiod"},
+				{Name: "base"},
+				{Name: "removed"},
+			},
+		},
+	}
+
+	var got []string
+	for _, i := range uninstallOrder(helmApp) {
+		got = append(got, helmApp.Status.Components[i].Name)
+	}
+	want := []string{"removed", "gateway", "istiod", "base"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("uninstallOrder() mismatch (-want +got):\n%s", diff)
+	}
+}
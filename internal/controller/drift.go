@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/utils/ptr"
+)
+
+// Drift policies of a component
+const (
+	driftPolicyIgnore  = "ignore"
+	driftPolicyReport  = "report"
+	driftPolicyCorrect = "correct"
+)
+
+// driftFieldManager is the field manager used when re-applying drifted objects
+const driftFieldManager = "pluma-operator"
+
+// maxDriftedFields limits the fields listed in the drift message of a resource
+const maxDriftedFields = 5
+
+// driftPolicy returns the drift policy of the component, reporting drift by default.
+func driftPolicy(policy string) string {
+	if policy == "" {
+		return driftPolicyReport
+	}
+	return policy
+}
+
+// detectDrift compares the object rendered in the release manifest with the live object and
+// returns a message describing the drift, or an empty string if the live object matches.
+// Only the fields set in the manifest are compared, so fields defaulted or added by the API
+// server or other controllers are not drift. A nil live object means the resource is missing.
+func detectDrift(desired, live *unstructured.Unstructured) string {
+	if live == nil {
+		return "resource not found"
+	}
+	fields := driftedFields(desired.Object, live.Object)
+	if len(fields) == 0 {
+		return ""
+	}
+	if len(fields) > maxDriftedFields {
+		fields = append(fields[:maxDriftedFields], fmt.Sprintf("and %d more", len(fields)-maxDriftedFields))
+	}
+	return fmt.Sprintf("fields differ from the release manifest: %s", strings.Join(fields, ", "))
+}
+
+// driftedFields returns the sorted paths of the fields of desired which differ in live.
+func driftedFields(desired, live map[string]any) []string {
+	desired = normalizeSecret(desired)
+	var paths []string
+	for k, dv := range desired {
+		switch k {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			dm, _ := dv.(map[string]any)
+			lm, _ := live[k].(map[string]any)
+			for _, field := range []string{"labels", "annotations"} {
+				diffValue("metadata."+field, dm[field], lm[field], &paths)
+			}
+		default:
+			diffValue(k, dv, live[k], &paths)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func diffValue(path string, desired, live any, paths *[]string) {
+	switch d := desired.(type) {
+	case nil:
+		return
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			if len(d) > 0 || live != nil {
+				*paths = append(*paths, path)
+			}
+			return
+		}
+		for k, v := range d {
+			diffValue(path+"."+k, v, l[k], paths)
+		}
+	case []any:
+		l, ok := live.([]any)
+		if !ok || len(l) != len(d) {
+			if len(d) > 0 || live != nil {
+				*paths = append(*paths, path)
+			}
+			return
+		}
+		for i := range d {
+			diffValue(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], paths)
+		}
+	case string:
+		// Empty strings are left for the API server or other controllers to fill in, e.g. a caBundle
+		if d != "" && !scalarEqual(d, live) {
+			*paths = append(*paths, path)
+		}
+	default:
+		if !scalarEqual(d, live) {
+			*paths = append(*paths, path)
+		}
+	}
+}
+
+func scalarEqual(desired, live any) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	if df, ok := toFloat(desired); ok {
+		if lf, ok := toFloat(live); ok {
+			return df == lf
+		}
+	}
+	ds, dok := desired.(string)
+	ls, lok := live.(string)
+	if dok && lok {
+		// Quantities are normalized by the API server, e.g. 1000m becomes 1
+		dq, derr := apiresource.ParseQuantity(ds)
+		lq, lerr := apiresource.ParseQuantity(ls)
+		return derr == nil && lerr == nil && dq.Cmp(lq) == 0
+	}
+	return fmt.Sprint(desired) == fmt.Sprint(live)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// normalizeSecret moves the stringData of a Secret into its data, as the API server does.
+func normalizeSecret(obj map[string]any) map[string]any {
+	if obj["kind"] != "Secret" {
+		return obj
+	}
+	stringData, ok := obj["stringData"].(map[string]any)
+	if !ok {
+		return obj
+	}
+	obj = (&unstructured.Unstructured{Object: obj}).DeepCopy().Object
+	data, _ := obj["data"].(map[string]any)
+	if data == nil {
+		data = map[string]any{}
+	}
+	for k, v := range stringData {
+		data[k] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(v)))
+	}
+	obj["data"] = data
+	delete(obj, "stringData")
+	return obj
+}
+
+// correctDrift re-applies the object rendered in the release manifest with server-side apply,
+// taking over the drifted fields, or creates it again if it was deleted.
+func correctDrift(info *resource.Info, desired *unstructured.Unstructured, notFound bool) error {
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	if notFound {
+		_, err := helper.Create(info.Namespace, true, desired)
+		if errors2.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	_, err = helper.Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{
+		FieldManager: driftFieldManager,
+		Force:        ptr.To(true),
+	})
+	return err
+}
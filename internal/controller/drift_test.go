@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_detectDrift(t *testing.T) {
+	desired := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":              "istiod",
+			"creationTimestamp": nil,
+			"labels":            map[string]any{"app": "istiod"},
+		},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{map[string]any{
+						"name":      "discovery",
+						"resources": map[string]any{"requests": map[string]any{"cpu": "1000m"}},
+					}},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name string
+		live *unstructured.Unstructured
+		want string
+	}{
+		{
+			name: "defaulted fields are not drift",
+			live: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]any{
+					"name":            "istiod",
+					"resourceVersion": "42",
+					"labels":          map[string]any{"app": "istiod", "extra": "label"},
+				},
+				"spec": map[string]any{
+					"replicas":             int64(1),
+					"revisionHistoryLimit": int64(10),
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{map[string]any{
+								"name":                     "discovery",
+								"terminationMessagePath":   "/dev/termination-log",
+								"resources":                map[string]any{"requests": map[string]any{"cpu": "1"}},
+								"terminationMessagePolicy": "File",
+							}},
+						},
+					},
+				},
+				"status": map[string]any{"replicas": int64(1)},
+			}},
+		},
+		{
+			name: "changed fields",
+			live: &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{
+					"name":   "istiod",
+					"labels": map[string]any{"app": "other"},
+				},
+				"spec": map[string]any{
+					"replicas": int64(3),
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{map[string]any{
+								"name":      "discovery",
+								"resources": map[string]any{"requests": map[string]any{"cpu": "1000m"}},
+							}},
+						},
+					},
+				},
+			}},
+			want: "fields differ from the release manifest: metadata.labels.app, spec.replicas",
+		},
+		{
+			name: "deleted",
+			want: "resource not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectDrift(desired, tt.live); got != tt.want {
+				t.Errorf("detectDrift() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_driftedFields_secret(t *testing.T) {
+	desired := map[string]any{
+		"kind":       "Secret",
+		"stringData": map[string]any{"token": "abc"},
+	}
+	live := map[string]any{
+		"kind": "Secret",
+		"data": map[string]any{"token": "YWJj"},
+	}
+	if got := driftedFields(desired, live); len(got) != 0 {
+		t.Errorf("driftedFields() = %v, want no drift", got)
+	}
+}
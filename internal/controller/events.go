@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"fmt"
+
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+// eventRecorderName is the source of the events emitted on HelmApps
+const eventRecorderName = "helmapp-controller"
+
+// Event reasons
+const (
+	eventReasonInstalled        = "Installed"
+	eventReasonInstallFailed    = "InstallFailed"
+	eventReasonUpgraded         = "Upgraded"
+	eventReasonUpgradeFailed    = "UpgradeFailed"
+	eventReasonUpgradeSkipped   = "UpgradeSkipped"
+	eventReasonRolledBack       = "RolledBack"
+	eventReasonRollbackFailed   = "RollbackFailed"
+	eventReasonRetriesExhausted = "RetriesExhausted"
+	eventReasonUninstalled      = "Uninstalled"
+	eventReasonUninstallFailed  = "UninstallFailed"
+	eventReasonOrphaned         = "Orphaned"
+	eventReasonAdopted          = "Adopted"
+	eventReasonChartFailed      = "ChartFailed"
+	eventReasonValuesFailed     = "ValuesFailed"
+	eventReasonInvalidSpec      = "InvalidSpec"
+	eventReasonPlanned          = "Planned"
+	eventReasonPlanFailed       = "PlanFailed"
+	eventReasonTestSucceeded    = "TestSucceeded"
+	eventReasonTestFailed       = "TestFailed"
+	eventReasonCRDsFailed       = "CRDsFailed"
+	eventReasonClusterFailed    = "ClusterFailed"
+)
+
+// componentEvent emits an event on the HelmApp about one of its components, naming the chart version.
+func (r *HelmAppReconciler) componentEvent(helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
+	eventType, reason, messageFmt string, args ...any) {
+	version := component.GetVersion()
+	if version == "" {
+		version = "latest"
+	}
+	r.event(helmApp, eventType, reason, "component %s (chart %s %s): %s",
+		component.GetName(), component.GetChart(), version, fmt.Sprintf(messageFmt, args...))
+}
+
+// event emits an event on the HelmApp if the reconciler has a recorder.
+func (r *HelmAppReconciler) event(helmApp *operatorv1alpha1.HelmApp, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(helmApp, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"testing"
+
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func Test_componentEvent(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	r := &HelmAppReconciler{Recorder: recorder}
+	helmApp := &operatorv1alpha1.HelmApp{}
+
+	r.componentEvent(helmApp, &operatorv1alpha1.HelmComponent{Name: "istiod", Chart: "istiod", Version: "1.21.1"},
+		corev1.EventTypeNormal, eventReasonUpgraded, "upgraded to revision %d", 2)
+	r.componentEvent(helmApp, &operatorv1alpha1.HelmComponent{Name: "demo", Chart: "demo"},
+		corev1.EventTypeWarning, eventReasonInstallFailed, "failed to install release: %v", "timed out")
+
+	for _, want := range []string{
+		"Normal Upgraded component istiod (chart istiod 1.21.1): upgraded to revision 2",
+		"Warning InstallFailed component demo (chart demo latest): failed to install release: timed out",
+	} {
+		if got := <-recorder.Events; got != want {
+			t.Errorf("componentEvent() = %q, want %q", got, want)
+		}
+	}
+}
+
+func Test_releaseStatusChanged(t *testing.T) {
+	release := &helmrelease.Release{Version: 3, Info: &helmrelease.Info{Status: helmrelease.StatusDeployed}}
+	tests := []struct {
+		name     string
+		previous *operatorv1alpha1.HelmComponentStatus
+		want     bool
+	}{
+		{name: "resync", previous: &operatorv1alpha1.HelmComponentStatus{Version: "3", Status: "deployed"}},
+		{name: "first reconcile", previous: nil, want: true},
+		{name: "new revision", previous: &operatorv1alpha1.HelmComponentStatus{Version: "2", Status: "deployed"}, want: true},
+		{name: "failed release", previous: &operatorv1alpha1.HelmComponentStatus{Version: "3", Status: "failed"}, want: true},
+		{name: "repeated error", previous: &operatorv1alpha1.HelmComponentStatus{Version: "3", Status: "deployed",
+			Message: "failed to correct drift of Deployment istiod: timed out"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := releaseStatusChanged(tt.previous, release); got != tt.want {
+				t.Errorf("releaseStatusChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+// Health of a resource, named after the kstatus statuses
+const (
+	healthCurrent    = "Current"
+	healthInProgress = "InProgress"
+	healthFailed     = "Failed"
+	healthNotFound   = "NotFound"
+	healthUnknown    = "Unknown"
+)
+
+var (
+	deploymentKind  = schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	statefulSetKind = schema.GroupKind{Group: "apps", Kind: "StatefulSet"}
+	daemonSetKind   = schema.GroupKind{Group: "apps", Kind: "DaemonSet"}
+	jobKind         = schema.GroupKind{Group: "batch", Kind: "Job"}
+	podKind         = schema.GroupKind{Kind: "Pod"}
+	pvcKind         = schema.GroupKind{Kind: "PersistentVolumeClaim"}
+	serviceKind     = schema.GroupKind{Kind: "Service"}
+	crdKind         = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+)
+
+// resourceHealth computes the health of the live object of info, refreshed by info.Get, and a
+// message explaining it. getErr is the error returned by info.Get.
+func resourceHealth(info *resource.Info, getErr error) (string, string) {
+	if err := getErr; err != nil {
+		if errors2.IsNotFound(err) {
+			return healthNotFound, "resource not found"
+		}
+		return healthUnknown, fmt.Sprintf("failed to get resource: %v", err)
+	}
+	obj, ok := info.Object.(*unstructured.Unstructured)
+	if !ok {
+		return healthCurrent, ""
+	}
+	return objectHealth(info.Mapping.GroupVersionKind.GroupKind(), obj)
+}
+
+func objectHealth(gk schema.GroupKind, obj *unstructured.Unstructured) (string, string) {
+	var (
+		health, message string
+		err             error
+	)
+	switch gk {
+	case deploymentKind:
+		deploy := &appsv1.Deployment{}
+		if err = fromUnstructured(obj, deploy); err == nil {
+			health, message = deploymentHealth(deploy)
+		}
+	case statefulSetKind:
+		sts := &appsv1.StatefulSet{}
+		if err = fromUnstructured(obj, sts); err == nil {
+			health, message = statefulSetHealth(sts)
+		}
+	case daemonSetKind:
+		ds := &appsv1.DaemonSet{}
+		if err = fromUnstructured(obj, ds); err == nil {
+			health, message = daemonSetHealth(ds)
+		}
+	case jobKind:
+		job := &batchv1.Job{}
+		if err = fromUnstructured(obj, job); err == nil {
+			health, message = jobHealth(job)
+		}
+	case podKind:
+		pod := &corev1.Pod{}
+		if err = fromUnstructured(obj, pod); err == nil {
+			health, message = podHealth(pod)
+		}
+	case pvcKind:
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err = fromUnstructured(obj, pvc); err == nil {
+			health, message = pvcHealth(pvc)
+		}
+	case serviceKind:
+		svc := &corev1.Service{}
+		if err = fromUnstructured(obj, svc); err == nil {
+			health, message = serviceHealth(svc)
+		}
+	case crdKind:
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err = fromUnstructured(obj, crd); err == nil {
+			health, message = crdHealth(crd)
+		}
+	default:
+		// Resources without a known readiness are current once they exist
+		return healthCurrent, ""
+	}
+	if err != nil {
+		return healthUnknown, fmt.Sprintf("failed to convert resource: %v", err)
+	}
+	return health, message
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, out any) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), out)
+}
+
+func deploymentHealth(deploy *appsv1.Deployment) (string, string) {
+	if deploy.Generation > deploy.Status.ObservedGeneration {
+		return healthInProgress, "waiting for the rollout to be observed"
+	}
+	rolledOut := false
+	var unavailable *appsv1.DeploymentCondition
+	for i, c := range deploy.Status.Conditions {
+		switch {
+		case c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded":
+			return healthFailed, c.Message
+		case c.Type == appsv1.DeploymentProgressing && c.Reason == "NewReplicaSetAvailable":
+			rolledOut = true
+		case c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionFalse:
+			unavailable = &deploy.Status.Conditions[i]
+		}
+	}
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	if deploy.Status.UpdatedReplicas < replicas {
+		return healthInProgress, fmt.Sprintf("%d of %d replicas updated", deploy.Status.UpdatedReplicas, replicas)
+	}
+	if deploy.Status.Replicas > deploy.Status.UpdatedReplicas {
+		return healthInProgress, fmt.Sprintf("%d old replicas pending termination", deploy.Status.Replicas-deploy.Status.UpdatedReplicas)
+	}
+	// The rollout completed and the replicas went down since, like when they crash-loop
+	if rolledOut && unavailable != nil {
+		return healthFailed, unavailable.Message
+	}
+	if deploy.Status.AvailableReplicas < replicas {
+		return healthInProgress, fmt.Sprintf("%d of %d replicas available", deploy.Status.AvailableReplicas, replicas)
+	}
+	return healthCurrent, ""
+}
+
+func statefulSetHealth(sts *appsv1.StatefulSet) (string, string) {
+	if sts.Generation > sts.Status.ObservedGeneration {
+		return healthInProgress, "waiting for the rollout to be observed"
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return healthCurrent, ""
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if sts.Status.ReadyReplicas < replicas {
+		return healthInProgress, fmt.Sprintf("%d of %d replicas ready", sts.Status.ReadyReplicas, replicas)
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 {
+		if expected := replicas - *ru.Partition; sts.Status.UpdatedReplicas < expected {
+			return healthInProgress, fmt.Sprintf("%d of %d replicas updated", sts.Status.UpdatedReplicas, expected)
+		}
+		return healthCurrent, ""
+	}
+	if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return healthInProgress, fmt.Sprintf("%d of %d replicas updated", sts.Status.UpdatedReplicas, replicas)
+	}
+	return healthCurrent, ""
+}
+
+func daemonSetHealth(ds *appsv1.DaemonSet) (string, string) {
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return healthInProgress, "waiting for the rollout to be observed"
+	}
+	if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return healthCurrent, ""
+	}
+	desired := ds.Status.DesiredNumberScheduled
+	if ds.Status.UpdatedNumberScheduled < desired {
+		return healthInProgress, fmt.Sprintf("%d of %d pods updated", ds.Status.UpdatedNumberScheduled, desired)
+	}
+	if ds.Status.NumberAvailable < desired {
+		return healthInProgress, fmt.Sprintf("%d of %d pods available", ds.Status.NumberAvailable, desired)
+	}
+	return healthCurrent, ""
+}
+
+func jobHealth(job *batchv1.Job) (string, string) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobFailed:
+			return healthFailed, c.Message
+		case batchv1.JobComplete:
+			return healthCurrent, ""
+		}
+	}
+	return healthInProgress, fmt.Sprintf("%d pods active, %d succeeded", job.Status.Active, job.Status.Succeeded)
+}
+
+func podHealth(pod *corev1.Pod) (string, string) {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return healthCurrent, ""
+	case corev1.PodFailed:
+		return healthFailed, pod.Status.Message
+	case corev1.PodRunning:
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return healthCurrent, ""
+			}
+		}
+		return healthInProgress, "pod is not ready"
+	}
+	return healthInProgress, fmt.Sprintf("pod is %s", pod.Status.Phase)
+}
+
+func pvcHealth(pvc *corev1.PersistentVolumeClaim) (string, string) {
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return healthCurrent, ""
+	case corev1.ClaimLost:
+		return healthFailed, "persistent volume claim lost its volume"
+	}
+	return healthInProgress, "persistent volume claim is not bound"
+}
+
+func serviceHealth(svc *corev1.Service) (string, string) {
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return healthInProgress, "waiting for the load balancer to be assigned"
+	}
+	return healthCurrent, ""
+}
+
+func crdHealth(crd *apiextensionsv1.CustomResourceDefinition) (string, string) {
+	for _, c := range crd.Status.Conditions {
+		switch {
+		case c.Type == apiextensionsv1.Established && c.Status == apiextensionsv1.ConditionTrue:
+			return healthCurrent, ""
+		case c.Type == apiextensionsv1.NamesAccepted && c.Status == apiextensionsv1.ConditionFalse:
+			return healthFailed, c.Message
+		}
+	}
+	return healthInProgress, "waiting for the CRD to be established"
+}
+
+// resourcesHealth aggregates the health of the resources of a component. A failed resource fails
+// the component, any other resource which is not current keeps it in progress.
+func resourcesHealth(resources []*operatorv1alpha1.HelmResourceStatus) string {
+	health := healthCurrent
+	for _, res := range resources {
+		switch res.GetHealth() {
+		case healthFailed:
+			return healthFailed
+		case healthCurrent, "":
+		default:
+			health = healthInProgress
+		}
+	}
+	return health
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func Test_objectHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		gk          schema.GroupKind
+		obj         any
+		wantHealth  string
+		wantMessage string
+	}{
+		{
+			name: "deployment available",
+			gk:   deploymentKind,
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			},
+			wantHealth: healthCurrent,
+		},
+		{
+			name: "deployment rolling out",
+			gk:   deploymentKind,
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 3},
+			},
+			wantHealth:  healthInProgress,
+			wantMessage: "1 of 3 replicas updated",
+		},
+		{
+			name: "deployment not observed",
+			gk:   deploymentKind,
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2},
+			},
+			wantHealth:  healthInProgress,
+			wantMessage: "waiting for the rollout to be observed",
+		},
+		{
+			name: "deployment progress deadline exceeded",
+			gk:   deploymentKind,
+			obj: &appsv1.Deployment{
+				Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+					Type:    appsv1.DeploymentProgressing,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ProgressDeadlineExceeded",
+					Message: `ReplicaSet "istiod-5d4f" has timed out progressing.`,
+				}}},
+			},
+			wantHealth:  healthFailed,
+			wantMessage: `ReplicaSet "istiod-5d4f" has timed out progressing.`,
+		},
+		{
+			name: "deployment degraded after its rollout",
+			gk:   deploymentKind,
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+				Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1,
+					Conditions: []appsv1.DeploymentCondition{{
+						Type:   appsv1.DeploymentProgressing,
+						Status: corev1.ConditionTrue,
+						Reason: "NewReplicaSetAvailable",
+					}, {
+						Type:    appsv1.DeploymentAvailable,
+						Status:  corev1.ConditionFalse,
+						Reason:  "MinimumReplicasUnavailable",
+						Message: "Deployment does not have minimum availability.",
+					}}},
+			},
+			wantHealth:  healthFailed,
+			wantMessage: "Deployment does not have minimum availability.",
+		},
+		{
+			name: "deployment starting",
+			gk:   deploymentKind,
+			obj: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+				Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1,
+					Conditions: []appsv1.DeploymentCondition{{
+						Type:   appsv1.DeploymentProgressing,
+						Status: corev1.ConditionTrue,
+						Reason: "ReplicaSetUpdated",
+					}, {
+						Type:   appsv1.DeploymentAvailable,
+						Status: corev1.ConditionFalse,
+						Reason: "MinimumReplicasUnavailable",
+					}}},
+			},
+			wantHealth:  healthInProgress,
+			wantMessage: "0 of 1 replicas available",
+		},
+		{
+			name: "job failed",
+			gk:   jobKind,
+			obj: &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+				Type:    batchv1.JobFailed,
+				Status:  corev1.ConditionTrue,
+				Message: "Job has reached the specified backoff limit",
+			}}}},
+			wantHealth:  healthFailed,
+			wantMessage: "Job has reached the specified backoff limit",
+		},
+		{
+			name:       "pvc bound",
+			gk:         pvcKind,
+			obj:        &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound}},
+			wantHealth: healthCurrent,
+		},
+		{
+			name:        "load balancer pending",
+			gk:          serviceKind,
+			obj:         &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+			wantHealth:  healthInProgress,
+			wantMessage: "waiting for the load balancer to be assigned",
+		},
+		{
+			name:       "unknown kind",
+			gk:         schema.GroupKind{Kind: "ConfigMap"},
+			obj:        &corev1.ConfigMap{},
+			wantHealth: healthCurrent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotHealth, gotMessage := objectHealth(tt.gk, &unstructured.Unstructured{Object: content})
+			if gotHealth != tt.wantHealth || gotMessage != tt.wantMessage {
+				t.Errorf("objectHealth() = %v, %q, want %v, %q", gotHealth, gotMessage, tt.wantHealth, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func Test_resourcesHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*operatorv1alpha1.HelmResourceStatus
+		want      string
+	}{
+		{
+			name: "no resources",
+			want: healthCurrent,
+		},
+		{
+			name:      "all current",
+			resources: []*operatorv1alpha1.HelmResourceStatus{{Health: healthCurrent}, {Health: healthCurrent}},
+			want:      healthCurrent,
+		},
+		{
+			name:      "one missing",
+			resources: []*operatorv1alpha1.HelmResourceStatus{{Health: healthCurrent}, {Health: healthNotFound}},
+			want:      healthInProgress,
+		},
+		{
+			name:      "failure wins",
+			resources: []*operatorv1alpha1.HelmResourceStatus{{Health: healthInProgress}, {Health: healthFailed}},
+			want:      healthFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourcesHealth(tt.resources); got != tt.want {
+				t.Errorf("resourcesHealth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,34 +3,43 @@ package controller
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"pluma.io/pluma-opeartor/internal/pkg/tools"
 
 	"github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	helmaction "helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	helmcli "helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/registry"
 	helmrelease "helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/tools/record"
 	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"pluma.io/pluma-opeartor/config"
 	"pluma.io/pluma-opeartor/internal/pkg/constants"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 var settings = newHelmSettings()
@@ -54,19 +63,49 @@ func warning(format string, v ...interface{}) {
 // HelmAppReconciler reconciles a HelmApp object
 type HelmAppReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Config   config.Config
+	Recorder record.EventRecorder
+
+	charts *chartCache
+	// cluster reaches the cluster of the manager with its client config
+	cluster       *restConfigGetter
+	actionConfigs *actionConfigPool
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *HelmAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor(eventRecorderName)
+	charts, err := newChartCache(r.Config.ChartCacheDir, r.Config.ChartCacheMaxCharts, r.Config.ChartCacheMaxBytes)
+	if err != nil {
+		return err
+	}
+	r.charts = charts
+	if r.cluster, err = newRESTConfigGetter(mgr.GetConfig()); err != nil {
+		return err
+	}
+	r.actionConfigs = newActionConfigPool()
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha1.HelmApp{}).
+		// Status updates don't trigger a reconcile, the status records the time of every attempt
+		For(&operatorv1alpha1.HelmApp{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		))).
+		// Values read from ConfigMaps and Secrets are applied as soon as they change
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.helmAppsForValues(valuesKindConfigMap))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.helmAppsForValues(valuesKindSecret))).
+		// A new kubeconfig is used as soon as it is written
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.helmAppsForKubeConfig)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.helmAppsForClusterVariables)).
 		Complete(r)
 }
 
 const (
 	failedAfter       = 30 * time.Second
 	serverFailedAfter = 60 * time.Second
+	reconcileAfter    = 20 * time.Second
+	resyncAfter       = 5 * time.Minute
 )
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -93,16 +132,11 @@ func (r *HelmAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}
 
-	// Initialize Helm client
-	helmCfg, err := newActionConfiguration()
-	if err != nil {
-		return ctrl.Result{RequeueAfter: serverFailedAfter}, fmt.Errorf("failed to new Helm action config: %v", err)
-	}
-
-	// Get the local kubeconfig
-	restClientGetter := genericclioptions.NewConfigFlags(true)
-	if err := helmCfg.Init(restClientGetter, helmApp.Namespace, "", debug); err != nil {
-		return ctrl.Result{RequeueAfter: serverFailedAfter}, fmt.Errorf("failed to initialize Helm action config: %w", err)
+	// Helm clients of the release namespaces of the target cluster, shared with the other reconciles
+	configs, clusterErr := r.clusterActionConfigs(ctx, helmApp)
+	if clusterErr != nil {
+		cLog.Error(clusterErr, "Failed to reach the target cluster")
+		r.event(helmApp, corev1.EventTypeWarning, eventReasonClusterFailed, "%v", clusterErr)
 	}
 
 	// Create a map of desired components
@@ -111,13 +145,40 @@ func (r *HelmAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		desiredComponents[component.Name] = component
 	}
 
-	// Process each component
+	// Process each component after the components it depends on
 	var componentStatuses []*operatorv1alpha1.HelmComponentStatus
-	for _, component := range helmApp.Spec.Components {
-		status, err := r.reconcileComponent(ctx, helmApp, component, helmCfg)
+	statusByName := make(map[string]*operatorv1alpha1.HelmComponentStatus)
+	sortedComponents, specErr := sortComponents(helmApp.Spec.Components)
+	if specErr != nil {
+		// Invalid dependencies, leave all releases untouched
+		cLog.Error(specErr, "Invalid component dependencies")
+		r.event(helmApp, corev1.EventTypeWarning, eventReasonInvalidSpec, "invalid component dependencies: %v", specErr)
+		for _, component := range helmApp.Spec.Components {
+			status := previousStatus(helmApp, component.Name)
+			status.Status = helmrelease.StatusFailed.String()
+			status.Message = specErr.Error()
+			statusByName[component.Name] = status
+		}
+	} else if clusterErr != nil {
+		// The releases can't be reached, keep what was last reported about them
+		for _, component := range helmApp.Spec.Components {
+			status := previousStatus(helmApp, component.Name)
+			status.Message = clusterErr.Error()
+			status.Reason = errorReason(clusterErr)
+			statusByName[component.Name] = status
+		}
+	} else {
+		var err error
+		statusByName, err = r.reconcileComponents(ctx, helmApp, sortedComponents, configs)
 		if err != nil {
-			cLog.Error(err, fmt.Sprintf("Failed to reconcile component %s", component.Name))
+			cLog.Error(err, "Failed to reconcile components")
 		}
+	}
+	// Keep the status in spec order
+	for _, component := range helmApp.Spec.Components {
+		status := statusByName[component.Name]
+		status.Conditions = componentConditions(helmApp, component, status, statusByName, specErr)
+		recordComponentMetrics(helmApp, status)
 		componentStatuses = append(componentStatuses, status)
 	}
 
@@ -125,7 +186,12 @@ func (r *HelmAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if helmApp.Status != nil {
 		for _, existingStatus := range helmApp.Status.Components {
 			if _, exists := desiredComponents[existingStatus.Name]; !exists && existingStatus.Name != "" {
-				if err := r.uninstallComponent(ctx, existingStatus.Name, helmCfg); err != nil {
+				if helmApp.Spec.GetSuspend() || clusterErr != nil {
+					// Keep the release until the HelmApp is resumed or its cluster is reachable
+					componentStatuses = append(componentStatuses, existingStatus)
+					continue
+				}
+				if err := r.uninstallComponent(ctx, helmApp, existingStatus.Name, existingStatus.GetNamespace(), configs); err != nil {
 					cLog.Error(err, fmt.Sprintf("Failed to uninstall component %s", existingStatus.Name))
 					// Update component status with error message
 					componentStatuses = append(componentStatuses, &operatorv1alpha1.HelmComponentStatus{
@@ -138,6 +204,7 @@ func (r *HelmAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 					})
 				} else {
 					cLog.Info("Uninstalled component", "component", existingStatus.Name)
+					deleteComponentMetrics(helmApp, existingStatus.Name)
 				}
 			}
 		}
@@ -147,22 +214,44 @@ func (r *HelmAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if helmApp.Status == nil {
 		helmApp.Status = &operatorv1alpha1.HelmAppStatus{}
 	}
+	if err := r.syncPlan(ctx, helmApp, componentStatuses); err != nil {
+		cLog.Error(err, "Failed to sync the plan")
+	}
+	helmApp.Status.Conditions = appConditions(helmApp, componentStatuses)
 	helmApp.Status.Components = componentStatuses
 
 	// Calculate overall phase based on component statuses
 	overallPhase := calculateOverallPhase(helmApp, componentStatuses)
+	if clusterErr != nil {
+		overallPhase = operatorv1alpha1.Phase_FAILED
+	}
 	helmApp.Status.Phase = overallPhase
+	helmApp.Status.ObservedGeneration = helmApp.Generation
+	helmApp.Status.LastAttemptedTime = timestamppb.Now()
+	if overallPhase == operatorv1alpha1.Phase_SUCCEEDED {
+		helmApp.Status.LastSuccessfulTime = helmApp.Status.LastAttemptedTime
+	}
 
 	if err := r.Status().Update(ctx, helmApp); err != nil {
 		return ctrl.Result{RequeueAfter: serverFailedAfter}, fmt.Errorf("failed to update HelmApp status: %w", err)
 	}
 
+	// Nothing changes until the HelmApp is resumed
+	if helmApp.Spec.GetSuspend() {
+		return ctrl.Result{}, nil
+	}
+
 	// If the phase is FAILED, requeue after 3 minutes
 	if overallPhase == operatorv1alpha1.Phase_FAILED {
 		return ctrl.Result{RequeueAfter: failedAfter}, nil
 	}
+	// Components are still waiting for their dependencies or resources
+	if overallPhase == operatorv1alpha1.Phase_RECONCILING {
+		return ctrl.Result{RequeueAfter: reconcileAfter}, nil
+	}
 
-	return ctrl.Result{}, nil
+	// Check the health of the resources periodically
+	return ctrl.Result{RequeueAfter: resyncAfter}, nil
 }
 
 func calculateOverallPhase(helmApp *operatorv1alpha1.HelmApp, componentStatuses []*operatorv1alpha1.HelmComponentStatus) operatorv1alpha1.Phase {
@@ -181,7 +270,13 @@ func calculateOverallPhase(helmApp *operatorv1alpha1.HelmApp, componentStatuses
 		case helmrelease.StatusFailed.String():
 			hasFailure = true
 		case helmrelease.StatusDeployed.String(), helmrelease.StatusSuperseded.String():
-			// Do nothing, it's good
+			// The release is good, wait for its resources to be ready
+			switch resourcesHealth(status.GetResources()) {
+			case healthFailed:
+				hasFailure = true
+			case healthInProgress:
+				allDeployed = false
+			}
 		default:
 			allDeployed = false
 		}
@@ -199,48 +294,76 @@ func calculateOverallPhase(helmApp *operatorv1alpha1.HelmApp, componentStatuses
 func (r *HelmAppReconciler) reconcileDelete(ctx context.Context, helmApp *operatorv1alpha1.HelmApp) (ctrl.Result, error) {
 	cLog := ctllog.FromContext(ctx)
 
-	// Initialize Helm client
-	helmCfg, err := newActionConfiguration()
-	if err != nil {
-		return ctrl.Result{RequeueAfter: serverFailedAfter}, fmt.Errorf("failed to new Helm action config: %v", err)
-	}
-
-	// Get the local kubeconfig
-	restClientGetter := genericclioptions.NewConfigFlags(true)
-	if err := helmCfg.Init(restClientGetter, helmApp.Namespace, "", debug); err != nil {
-		return ctrl.Result{RequeueAfter: serverFailedAfter}, fmt.Errorf("failed to initialize Helm action config: %w", err)
+	// Helm clients of the release namespaces of the target cluster, shared with the other reconciles
+	configs, clusterErr := r.clusterActionConfigs(ctx, helmApp)
+	if clusterErr != nil {
+		cLog.Error(clusterErr, "Failed to reach the target cluster")
+		r.event(helmApp, corev1.EventTypeWarning, eventReasonClusterFailed, "%v", clusterErr)
 	}
 
-	// Uninstall all components in reverse order
+	// Uninstall all components in reverse dependency order
 	allComponentsUninstalled := true
-	if helmApp.Status != nil && len(helmApp.Status.Components) > 0 {
-		for i := len(helmApp.Status.Components) - 1; i >= 0; i-- {
+	if clusterErr != nil && helmApp.Status != nil && len(helmApp.Status.Components) > 0 {
+		// Nothing can be uninstalled until the cluster is reachable
+		allComponentsUninstalled = false
+		for _, component := range helmApp.Status.Components {
+			component.Message = clusterErr.Error()
+		}
+	} else if helmApp.Status != nil && len(helmApp.Status.Components) > 0 {
+		remaining := make([]bool, len(helmApp.Status.Components))
+		remainingNames := make(map[string]bool)
+		for _, i := range uninstallOrder(helmApp) {
 			component := helmApp.Status.Components[i]
-			cleanStatus := true
+			// Keep the component while components depending on it are still installed
+			if dependents := installedDependents(helmApp, component.Name, remainingNames); len(dependents) > 0 {
+				allComponentsUninstalled = false
+				component.Status = componentStatusWaiting
+				component.Message = fmt.Sprintf("waiting for dependents to be uninstalled: %s", strings.Join(dependents, ", "))
+				remaining[i] = true
+				remainingNames[component.Name] = true
+				continue
+			}
+			if isSuspended(helmApp, component.Name) {
+				// Keep the release until the component is resumed
+				allComponentsUninstalled = false
+				component.Message = "reconciliation is suspended, resume it to uninstall the release"
+				remaining[i] = true
+				remainingNames[component.Name] = true
+				continue
+			}
 			if component.Name != "" {
 				cLog.Info("Uninstalled component during deletion", "component", component.Name)
-				err := r.uninstallComponent(ctx, component.Name, helmCfg)
+				err := r.uninstallComponent(ctx, helmApp, component.Name, component.GetNamespace(), configs)
 				if err != nil {
 					cLog.Error(err, fmt.Sprintf("Failed to uninstall component %s during deletion", component.Name))
 					allComponentsUninstalled = false
 
 					err = fmt.Errorf("uninstall %s error: %v", component.Name, err)
 					// Update component status with error message
-					helmApp.Status.Components[i].Status = helmrelease.StatusFailed.String()
-					helmApp.Status.Components[i].Message = err.Error()
-					cleanStatus = false
+					component.Status = helmrelease.StatusFailed.String()
+					component.Message = err.Error()
+					remaining[i] = true
+					remainingNames[component.Name] = true
 				}
 			}
+		}
 
-			if cleanStatus {
-				// Remove the uninstalled component from the status
-				helmApp.Status.Components = append(helmApp.Status.Components[:i], helmApp.Status.Components[i+1:]...)
+		// Remove the uninstalled components from the status
+		var components []*operatorv1alpha1.HelmComponentStatus
+		for i, component := range helmApp.Status.Components {
+			if remaining[i] {
+				components = append(components, component)
 			}
 		}
+		helmApp.Status.Components = components
 	}
 
 	// Update HelmApp status
+	if helmApp.Status == nil {
+		helmApp.Status = &operatorv1alpha1.HelmAppStatus{}
+	}
 	helmApp.Status.Phase = calculateOverallPhase(helmApp, helmApp.Status.Components)
+	helmApp.Status.Conditions = deletingConditions(helmApp)
 	if err := r.Status().Update(ctx, helmApp); err != nil {
 		return ctrl.Result{RequeueAfter: serverFailedAfter}, fmt.Errorf("failed to update HelmApp status: %w", err)
 	}
@@ -251,6 +374,7 @@ func (r *HelmAppReconciler) reconcileDelete(ctx context.Context, helmApp *operat
 		if err := r.Update(ctx, helmApp); err != nil {
 			return ctrl.Result{RequeueAfter: serverFailedAfter}, err
 		}
+		deleteComponentMetrics(helmApp, "")
 		return ctrl.Result{}, nil
 	}
 
@@ -283,69 +407,226 @@ func newHelmSettings() *helmcli.EnvSettings {
 }
 
 func (r *HelmAppReconciler) reconcileComponent(ctx context.Context, helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
-	helmCfg *helmaction.Configuration) (componentStatus *operatorv1alpha1.HelmComponentStatus, err error) {
+	configs *actionConfigs) (componentStatus *operatorv1alpha1.HelmComponentStatus, err error) {
 	cLog := ctllog.FromContext(ctx)
 
-	values := tools.MergeMaps(helmApp.Spec.GlobalValues.AsMap(), component.ComponentValues.AsMap())
-	if component.IgnoreGlobalValues {
-		values = component.ComponentValues.AsMap()
-	}
-	// Create component status
+	// Create component status, keeping what was applied until the release changes
+	namespace := releaseNamespace(helmApp, component.GetTargetNamespace())
+	previous := previousStatus(helmApp, component.Name)
 	componentStatus = &operatorv1alpha1.HelmComponentStatus{
-		Name:    component.GetName(),
-		Status:  "unknown",
-		Version: "unknown",
+		Name:               component.GetName(),
+		Status:             "unknown",
+		Version:            "unknown",
+		LastRollback:       previous.GetLastRollback(),
+		LastTest:           previous.GetLastTest(),
+		Crds:               previous.GetCrds(),
+		AppliedValuesHash:  previous.GetAppliedValuesHash(),
+		AppliedPatchesHash: previous.GetAppliedPatchesHash(),
+		ChartDigest:        previous.GetChartDigest(),
+		Namespace:          namespace,
+	}
+
+	// The release moved to another namespace, it is uninstalled from the previous one before it is
+	// installed in the new one. The status keeps the previous namespace until the move is done.
+	previousNamespace := previous.GetNamespace()
+	moved := previousNamespace != "" && previousNamespace != namespace
+	if moved {
+		componentStatus.Namespace = previousNamespace
+		componentStatus.AppliedValuesHash = ""
+		componentStatus.AppliedPatchesHash = ""
+		componentStatus.ChartDigest = ""
+	}
+
+	helmCfg, err := configs.get(namespace)
+	if err != nil {
+		componentStatus.Message = err.Error()
+		return
+	}
+	if component.GetCreateNamespace() {
+		if err = ensureNamespace(ctx, configs.client, namespace, component.GetNamespaceMetadata()); err != nil {
+			componentStatus.Message = err.Error()
+			return
+		}
 	}
 
-	// Create a new install action
-	install := helmaction.NewInstall(helmCfg)
-	install.Namespace = helmApp.Namespace
-	install.ReleaseName = component.Name
-	install.Version = component.Version
-	install.RepoURL = helmApp.Spec.Repo.Url
-	install.ChartPathOptions.RepoURL = helmApp.Spec.Repo.Url
-
-	// Locate the chart
-	cp, err := install.ChartPathOptions.LocateChart(component.Chart, settings)
+	values, err := r.componentValues(ctx, helmApp, component)
 	if err != nil {
-		err = fmt.Errorf("failed to locate chart: %w", err)
+		err = fmt.Errorf("failed to read values: %w", err)
 		componentStatus.Message = err.Error()
+		r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonValuesFailed, "%v", err)
 		return
 	}
 
-	// Load Chart
-	chart, err := loader.Load(cp)
+	// Nothing changed since the release was deployed, skip locating and loading the chart. A moved
+	// release isn't done until it is uninstalled from its previous namespace.
+	if !moved {
+		if release := unchangedRelease(helmApp, component, previous, values, helmCfg); release != nil {
+			cLog.V(1).Info("Release is up to date", "component", component.Name)
+			return syncReleaseStatus(ctx, helmCfg, helmApp, component, componentStatus, release, &multierror.Error{})
+		}
+	}
+
+	// Resolve where the chart is pulled from, the component repo wins over the app repo
+	repo := resolveRepo(helmApp, component)
+	chartName, repoURL := chartReference(repo, component.Chart)
+
+	// Track failed attempts, a configuration which keeps failing isn't retried until it changes
+	policy := component.GetUpgradePolicy()
+	patches := patchesHash(component.GetPatches())
+	hash := configHash(chartName, component.Version, values, patches)
+	creds, err := r.loadRepoCredentials(ctx, helmApp.Namespace, repo)
 	if err != nil {
-		err = fmt.Errorf("failed to load chart: %w", err)
 		componentStatus.Message = err.Error()
+		r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonChartFailed, "%v", err)
 		return
 	}
+	defer creds.cleanup()
+
+	// Create a new install action
+	install := helmaction.NewInstall(helmCfg)
+	install.Namespace = namespace
+	install.ReleaseName = component.Name
+	install.Version = component.Version
+	install.RepoURL = repoURL
+	install.ChartPathOptions.RepoURL = repoURL
+	install.Atomic = policy.GetAtomic()
+	install.Timeout = upgradeTimeout(policy)
+	install.PostRenderer = newPostRenderer(component.GetPatches(), namespace)
+	creds.apply(&install.ChartPathOptions)
+	if registry.IsOCI(chartName) {
+		registryClient, err := creds.registryClient(chartName)
+		if err != nil {
+			componentStatus.Message = err.Error()
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonChartFailed, "%v", err)
+			return componentStatus, err
+		}
+		if registryClient != nil {
+			install.SetRegistryClient(registryClient)
+		}
+	}
+
+	// Pinned chart versions are loaded from the cache rather than downloaded again
+	key := chartKey{repoURL: repoURL, chart: chartName, version: component.Version, credentials: creds.digest}
+	chart, digest := r.charts.load(key)
+	observeChartCache(chart != nil)
+	if chart == nil {
+		// Locate the chart
+		locateStart := time.Now()
+		cp, err := install.ChartPathOptions.LocateChart(chartName, settings)
+		observeChartDownload(helmApp, component.Name, locateStart)
+		if err != nil {
+			err = fmt.Errorf("failed to locate chart: %w", err)
+			componentStatus.Message = err.Error()
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonChartFailed, "%v", err)
+			return componentStatus, err
+		}
+
+		// Load Chart
+		chart, err = loader.Load(cp)
+		if err != nil {
+			err = fmt.Errorf("failed to load chart: %w", err)
+			componentStatus.Message = err.Error()
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonChartFailed, "%v", err)
+			return componentStatus, err
+		}
+		if digest, err = r.charts.add(key, cp); err != nil {
+			cLog.Error(err, "failed to cache chart", "component", component.Name)
+		}
+	}
 
 	// Install or upgrade the release
 	var release *helmrelease.Release
+	applied := false
 	mErrs := &multierror.Error{}
 
 	histClient := helmaction.NewHistory(helmCfg)
 	histClient.Max = 1
 	history, err := histClient.Run(component.Name)
+
+	var current *helmrelease.Release
+	if len(history) > 0 {
+		current = history[0]
+	}
+
+	// In plan mode the changes wait for the approval of their plan
+	if helmApp.Spec.GetPlan() && !retriesExhausted(previous, policy, hash) {
+		plan, planErr := planComponent(helmCfg, namespace, component, previous, chart, values, history, err)
+		if planErr == nil && plan != nil && moved {
+			planErr = planMove(plan, component.Name, previousNamespace, configs)
+		}
+		if planErr != nil {
+			multierror.Append(mErrs, planErr)
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonPlanFailed, "%v", planErr)
+			return syncReleaseStatus(ctx, helmCfg, helmApp, component, componentStatus, current, mErrs)
+		}
+		if plan != nil && !planApproved(helmApp, previous, plan) {
+			cLog.Info("Waiting for the approval of the plan", "component", component.Name, "plan", plan.Hash)
+			componentStatus.Plan = plan
+			if previous.GetPlan().GetHash() != plan.Hash {
+				r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonPlanned,
+					"%s plan with %d resource changes is waiting for approval", plan.Action, len(plan.Changes))
+			}
+			return syncReleaseStatus(ctx, helmCfg, helmApp, component, componentStatus, current, mErrs)
+		}
+	}
+
+	// Uninstall the release from the namespace it moved away from
+	if moved && !retriesExhausted(previous, policy, hash) {
+		if moveErr := r.moveRelease(ctx, helmApp, component, previousNamespace, namespace, configs); moveErr != nil {
+			cLog.Error(moveErr, "failed to move release", "from", previousNamespace, "to", namespace)
+			multierror.Append(mErrs, moveErr)
+			return syncReleaseStatus(ctx, helmCfg, helmApp, component, componentStatus, current, mErrs)
+		}
+		cLog.Info("Moved release", "component", component.Name, "from", previousNamespace, "to", namespace)
+		componentStatus.Namespace = namespace
+	}
+
+	// The CRDs of the chart are managed by the operator rather than by helm
+	crdPolicy := component.GetCrds()
+	if crdPolicy != "" {
+		install.SkipCRDs = true
+	}
+	if (crdPolicy == crdPolicyCreate || crdPolicy == crdPolicyCreateReplace) && !retriesExhausted(previous, policy, hash) {
+		crds, crdErr := applyCRDs(ctx, configs.client, chart, crdPolicy)
+		if crdErr != nil {
+			cLog.Error(crdErr, "failed to apply CRDs")
+			multierror.Append(mErrs, crdErr)
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonCRDsFailed, "%v", crdErr)
+			return syncReleaseStatus(ctx, helmCfg, helmApp, component, componentStatus, current, mErrs)
+		}
+		componentStatus.Crds = crds
+		// Helm caches the resources of the cluster, let it see the new CRDs
+		configs.invalidate(helmCfg)
+	}
+
 	switch {
+	case retriesExhausted(previous, policy, hash):
+		componentStatus.Failures = previous.GetFailures()
+		componentStatus.FailedConfigHash = hash
+		err := fmt.Errorf("giving up after %d failed attempts, update the component to retry", previous.GetFailures())
+		multierror.Append(mErrs, err)
+		r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonRetriesExhausted, "%v", err)
+		if len(history) > 0 {
+			release = history[0]
+		}
 	case errors.Is(err, driver.ErrReleaseNotFound):
 		// force install
 		if _, ok := helmApp.Labels[constants.AllowForceUpgradeLabel]; ok {
 			install.DryRun = true
 			install.IsUpgrade = true
 
-			// Release doesn't exist, install it
+			// Release doesn't exist, render it to adopt the existing resources
 			release, err = install.Run(chart, values)
 			if err != nil {
 				cLog.Error(err, "failed to install release")
-				multierror.Append(mErrs, fmt.Errorf("failed to install release: %v", err))
+				multierror.Append(mErrs, fmt.Errorf("failed to install release: %w", err))
 			}
 			cLog.Info("Installed release", "component", component.Name)
+			adopted := 0
 
 			// Parse the release manifest to get resource statuses
 			resources, err := resource.NewBuilder(helmCfg.RESTClientGetter).RequireObject(true).
-				Unstructured().
+				Unstructured().NamespaceParam(namespace).DefaultNamespace().
 				Stream(bytes.NewBufferString(release.Manifest), "").
 				Do().Infos()
 			if err != nil {
@@ -360,7 +641,7 @@ func (r *HelmAppReconciler) reconcileComponent(ctx context.Context, helmApp *ope
 					obj.SetGroupVersionKind(re.Mapping.GroupVersionKind)
 					obj.SetName(re.Name)
 					key := client.ObjectKey{Namespace: re.Namespace, Name: re.Name}
-					err := r.Client.Get(ctx, key, obj)
+					err := configs.client.Get(ctx, key, obj)
 					if err != nil {
 						if !errors2.IsNotFound(err) {
 							cLog.Error(err, "failed to get resource")
@@ -389,55 +670,126 @@ func (r *HelmAppReconciler) reconcileComponent(ctx context.Context, helmApp *ope
 
 						if v, ok := cAnno["meta.helm.sh/release-namespace"]; !ok || v != component.Name {
 							needUpdate = true
-							cAnno["meta.helm.sh/release-namespace"] = helmApp.Namespace
+							cAnno["meta.helm.sh/release-namespace"] = namespace
 						}
 						obj.SetAnnotations(cAnno)
 
 						// update
 						if needUpdate {
 							cLog.Info("force update resourcelabels and annotation", "resource", re.Name)
-							if err := r.Client.Update(ctx, obj); err != nil {
+							if err := configs.client.Update(ctx, obj); err != nil {
 								cLog.Error(err, "failed to update resource", "resource", re.Name)
+							} else {
+								adopted++
 							}
 						}
 					}
 				}
 			}
 
+			if adopted > 0 {
+				r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonAdopted, "adopted %d existing resources", adopted)
+			}
+
 			install.DryRun = false
 			install.IsUpgrade = false
 		}
 
 		// Release doesn't exist, install it
+		start := time.Now()
 		release, err = install.Run(chart, values)
+		observeOperation(helmApp, component.Name, operationInstall, start, err)
 		if err != nil {
 			cLog.Error(err, "failed to install release")
-			multierror.Append(mErrs, fmt.Errorf("failed to install release: %v", err))
+			multierror.Append(mErrs, fmt.Errorf("failed to install release: %w", err))
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonInstallFailed, "failed to install release: %v", err)
+		} else {
+			r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonInstalled, "installed revision %d", release.Version)
+			componentStatus.AppliedPatchesHash = patches
+			applied = true
 		}
+		recordAttempt(componentStatus, previous, hash, err != nil)
 		cLog.Info("Installed release", "component", component.Name)
 	case err == nil:
 		// Release exists, check if update is needed
-		if len(history) > 0 && !hasConfigChanged(history[len(history)-1], values, component.Version) {
+		if len(history) > 0 && !hasConfigChanged(history[len(history)-1], values, component.Version) &&
+			patches == previous.GetAppliedPatchesHash() {
 			cLog.Info("No changes detected, skipping upgrade", "component", component.Name)
 			release = history[0]
+			// Resyncs and changes of the referenced values find nothing to do, only report a new state
+			if releaseStatusChanged(previous, release) {
+				r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonUpgradeSkipped, "no changes detected")
+			}
 		} else {
 			// Upgrade the release
 			upgrade := helmaction.NewUpgrade(helmCfg)
-			upgrade.Namespace = helmApp.Namespace
-			upgrade.RepoURL = helmApp.Spec.Repo.Url
+			upgrade.Namespace = namespace
+			upgrade.RepoURL = repoURL
 			upgrade.Version = component.Version
+			upgrade.Atomic = policy.GetAtomic()
+			upgrade.CleanupOnFail = policy.GetCleanupOnFail()
+			upgrade.Timeout = upgradeTimeout(policy)
+			upgrade.PostRenderer = install.PostRenderer
+			start := time.Now()
 			release, err = upgrade.Run(component.Name, chart, values)
+			observeOperation(helmApp, component.Name, operationUpgrade, start, err)
 			if err != nil {
 				cLog.Error(err, "failed to upgrade release")
-				multierror.Append(mErrs, fmt.Errorf("failed to upgrade release: %v", err))
+				multierror.Append(mErrs, fmt.Errorf("failed to upgrade release: %w", err))
+				r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonUpgradeFailed, "failed to upgrade release: %v", err)
+
+				start = time.Now()
+				rollback, deployed, rbErr := recoverFailedUpgrade(helmCfg, release, policy, err)
+				if rollback != nil || rbErr != nil {
+					observeOperation(helmApp, component.Name, operationRollback, start, rbErr)
+				}
+				switch {
+				case rbErr != nil:
+					cLog.Error(rbErr, "failed to roll back release")
+					multierror.Append(mErrs, rbErr)
+					r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonRollbackFailed, "%v", rbErr)
+				case rollback != nil:
+					cLog.Info("Rolled back release", "component", component.Name, "revision", rollback.ToRevision)
+					componentStatus.LastRollback = rollback
+					release = deployed
+					r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonRolledBack,
+						"rolled back revision %d to revision %d", rollback.FromRevision, rollback.ToRevision)
+				}
+			} else {
+				r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonUpgraded, "upgraded to revision %d", release.Version)
+				componentStatus.AppliedPatchesHash = patches
+				applied = true
 			}
+			recordAttempt(componentStatus, previous, hash, err != nil)
 			cLog.Info("Upgraded release", "component", component.Name)
 		}
 	default:
 		cLog.Error(err, "helm releases history")
-		multierror.Append(mErrs, fmt.Errorf("helm releases history: %v", err))
+		multierror.Append(mErrs, fmt.Errorf("helm releases history: %w", err))
+	}
+	if mErrs.ErrorOrNil() == nil {
+		componentStatus.ChartDigest = digest
 	}
 
+	// Run the chart tests against the new revision
+	if applied && component.GetTest().GetEnable() {
+		tested := release
+		release = r.testRelease(ctx, helmApp, component, helmCfg, release, componentStatus, previous, hash, mErrs)
+		if release != tested {
+			// Rolled back to the revision deployed with the previous patches
+			componentStatus.AppliedPatchesHash = previous.GetAppliedPatchesHash()
+		}
+	}
+
+	return syncReleaseStatus(ctx, helmCfg, helmApp, component, componentStatus, release, mErrs)
+}
+
+// syncReleaseStatus fills the component status from the release and the live state of its resources.
+func syncReleaseStatus(ctx context.Context, helmCfg *helmaction.Configuration, helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
+	componentStatus *operatorv1alpha1.HelmComponentStatus, release *helmrelease.Release,
+	mErrs *multierror.Error) (*operatorv1alpha1.HelmComponentStatus, error) {
+	cLog := ctllog.FromContext(ctx)
+
 	version := "unknown"
 	status := "unknown"
 	var resourcesStatus []*operatorv1alpha1.HelmResourceStatus
@@ -446,22 +798,58 @@ func (r *HelmAppReconciler) reconcileComponent(ctx context.Context, helmApp *ope
 	if release != nil {
 		version = strconv.Itoa(release.Version)
 		status = release.Info.Status.String()
+		if release.Info.Status == helmrelease.StatusDeployed {
+			componentStatus.AppliedValuesHash = valuesHash(release.Config)
+		}
 
 		// Parse the release manifest to get resource statuses
 		resources, err := resource.NewBuilder(helmCfg.RESTClientGetter).
 			Unstructured().
+			NamespaceParam(release.Namespace).DefaultNamespace().
 			Stream(bytes.NewBufferString(release.Manifest), "").
 			Do().Infos()
 		if err != nil {
 			cLog.Error(err, "failed to parse release manifest")
 		} else {
 			resourcesTotal = len(resources)
+			policy := driftPolicy(component.DriftPolicy)
+			checkDrift := policy != driftPolicyIgnore && release.Info.Status == helmrelease.StatusDeployed
+			deletion := deletionPolicy(helmApp, component.Name)
 			for _, r := range resources {
+				desired, _ := r.Object.(*unstructured.Unstructured)
+				keep := keptByPolicy(deletion, r.Mapping.GroupVersionKind.Kind) || desired != nil && hasKeepPolicy(desired.GetAnnotations())
+				getErr := r.Get()
+				health, message := resourceHealth(r, getErr)
 				resourceStatus := &operatorv1alpha1.HelmResourceStatus{
 					ApiVersion: r.Mapping.GroupVersionKind.GroupVersion().String(),
 					Kind:       r.Mapping.GroupVersionKind.Kind,
 					Name:       r.Name,
 					Namespace:  r.Namespace,
+					Health:     health,
+					Message:    message,
+					Keep:       keep,
+				}
+				notFound := errors2.IsNotFound(getErr)
+				if checkDrift && desired != nil && (getErr == nil || notFound) {
+					live, _ := r.Object.(*unstructured.Unstructured)
+					if notFound {
+						live = nil
+					}
+					if drift := detectDrift(desired, live); drift != "" {
+						resourceStatus.Drifted = true
+						resourceStatus.DriftMessage = drift
+						componentStatus.DriftedResources++
+						cLog.Info("Resource drifted from the release manifest", "kind", resourceStatus.Kind,
+							"name", r.Name, "drift", drift)
+						if policy == driftPolicyCorrect {
+							if err := correctDrift(r, desired, notFound); err != nil {
+								cLog.Error(err, "failed to correct drift", "kind", resourceStatus.Kind, "name", r.Name)
+								multierror.Append(mErrs, fmt.Errorf("failed to correct drift of %s %s: %w", resourceStatus.Kind, r.Name, err))
+							} else {
+								resourceStatus.DriftMessage = "corrected drift: " + drift
+							}
+						}
+					}
 				}
 				resourcesStatus = append(resourcesStatus, resourceStatus)
 			}
@@ -479,9 +867,36 @@ func (r *HelmAppReconciler) reconcileComponent(ctx context.Context, helmApp *ope
 	return componentStatus, mErrs.ErrorOrNil()
 }
 
-func (r *HelmAppReconciler) uninstallComponent(ctx context.Context, componentName string, helmCfg *helmaction.Configuration) error {
+// resolveRepo returns the repo of the component if it defines one, otherwise the repo of the HelmApp.
+func resolveRepo(helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent) *operatorv1alpha1.HelmRepo {
+	if component.GetRepo().GetUrl() != "" {
+		return component.GetRepo()
+	}
+	return helmApp.Spec.GetRepo()
+}
+
+// chartReference returns the chart name and repo url to locate a chart with.
+// Charts in an OCI registry are referenced by their full oci:// url and pulled
+// through the registry client, so the repo url must stay empty for them.
+func chartReference(repo *operatorv1alpha1.HelmRepo, chart string) (name, repoURL string) {
+	if registry.IsOCI(chart) {
+		return chart, ""
+	}
+	if url := repo.GetUrl(); registry.IsOCI(url) {
+		return strings.TrimSuffix(url, "/") + "/" + chart, ""
+	}
+	return chart, repo.GetUrl()
+}
+
+func (r *HelmAppReconciler) uninstallComponent(ctx context.Context, helmApp *operatorv1alpha1.HelmApp, componentName, namespace string,
+	configs *actionConfigs) error {
 	cLog := ctllog.FromContext(ctx)
 
+	helmCfg, err := configs.get(releaseNamespace(helmApp, namespace))
+	if err != nil {
+		return err
+	}
+
 	// check
 	getAction := helmaction.NewGet(helmCfg)
 	cRelease, err := getAction.Run(componentName)
@@ -493,15 +908,80 @@ func (r *HelmAppReconciler) uninstallComponent(ctx context.Context, componentNam
 		return nil
 	}
 
+	component := &operatorv1alpha1.HelmComponent{Name: componentName}
+	if cRelease.Chart != nil && cRelease.Chart.Metadata != nil {
+		component.Chart = cRelease.Chart.Metadata.Name
+		component.Version = cRelease.Chart.Metadata.Version
+	}
+
+	policy := deletionPolicy(helmApp, componentName)
+	start := time.Now()
+	if policy == deletionPolicyOrphan {
+		// Drop the release, its resources stay in the cluster
+		err = orphanRelease(helmCfg, componentName)
+		observeOperation(helmApp, componentName, operationUninstall, start, err)
+		if err != nil {
+			cLog.Error(err, fmt.Sprintf("Failed to orphan component %s", componentName))
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonUninstallFailed, "failed to orphan release: %v", err)
+			return err
+		}
+		r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonOrphaned, "dropped release, its resources were kept")
+		return nil
+	}
+	if kinds := keptKinds(policy); kinds != nil {
+		if _, err := keepResources(helmCfg, cRelease, kinds); err != nil {
+			cLog.Error(err, fmt.Sprintf("Failed to keep resources of component %s", componentName))
+			r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonUninstallFailed, "failed to keep resources: %v", err)
+			return err
+		}
+	}
+
 	uninstall := helmaction.NewUninstall(helmCfg)
 	_, err = uninstall.Run(componentName)
+	observeOperation(helmApp, componentName, operationUninstall, start, err)
 	if err == nil || errors.Is(err, driver.ErrReleaseNotFound) {
+		if kept := keptResources(cRelease.Manifest); len(kept) > 0 {
+			r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonUninstalled,
+				"uninstalled release, kept %s", strings.Join(kept, ", "))
+		} else {
+			r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonUninstalled, "uninstalled release")
+		}
 		return nil
 	}
 	cLog.Error(err, fmt.Sprintf("Failed to uninstall component %s", componentName))
+	r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonUninstallFailed, "failed to uninstall release: %v", err)
 	return err
 }
 
+// unchangedRelease returns the last release of the component if it is deployed from the current
+// generation of the HelmApp with the same values and patches, so the chart doesn't need to be located again.
+func unchangedRelease(helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
+	previous *operatorv1alpha1.HelmComponentStatus, values map[string]any, helmCfg *helmaction.Configuration) *helmrelease.Release {
+	if helmApp.Status.GetObservedGeneration() != helmApp.Generation || previous.GetAppliedValuesHash() != valuesHash(values) ||
+		previous.GetAppliedPatchesHash() != patchesHash(component.GetPatches()) {
+		return nil
+	}
+	release, err := helmCfg.Releases.Last(component.Name)
+	if err != nil || release.Info.Status != helmrelease.StatusDeployed || hasConfigChanged(release, values, component.Version) {
+		return nil
+	}
+	return release
+}
+
+// releaseStatusChanged tells whether the component status recorded another revision or state of
+// the release. Errors of the resources, like a failed drift correction, don't count, they repeat on
+// every resync.
+func releaseStatusChanged(previous *operatorv1alpha1.HelmComponentStatus, release *helmrelease.Release) bool {
+	return previous.GetVersion() != strconv.Itoa(release.Version) || previous.GetStatus() != release.Info.Status.String()
+}
+
+// valuesHash returns the SHA-256 of the values.
+func valuesHash(values map[string]any) string {
+	data, _ := json.Marshal(values)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 func hasConfigChanged(release *helmrelease.Release, newValues map[string]any, newVersion string) bool {
 	if release.Chart.Metadata.Version != newVersion {
 		return true
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"testing"
+
+	helmaction "helm.sh/helm/v3/pkg/action"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func Test_chartReference(t *testing.T) {
+	type args struct {
+		repo  *operatorv1alpha1.HelmRepo
+		chart string
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		wantName    string
+		wantRepoURL string
+	}{
+		{
+			name: "http repo",
+			args: args{
+				repo:  &operatorv1alpha1.HelmRepo{Name: "istio", Url: "https://istio-release.storage.googleapis.com/charts"},
+				chart: "base",
+			},
+			wantName:    "base",
+			wantRepoURL: "https://istio-release.storage.googleapis.com/charts",
+		},
+		{
+			name: "oci repo",
+			args: args{
+				repo:  &operatorv1alpha1.HelmRepo{Name: "registry", Url: "oci://registry.example.com/charts/"},
+				chart: "gateway",
+			},
+			wantName:    "oci://registry.example.com/charts/gateway",
+			wantRepoURL: "",
+		},
+		{
+			name: "oci chart reference",
+			args: args{
+				repo:  &operatorv1alpha1.HelmRepo{Name: "istio", Url: "https://istio-release.storage.googleapis.com/charts"},
+				chart: "oci://registry.example.com/charts/istiod",
+			},
+			wantName:    "oci://registry.example.com/charts/istiod",
+			wantRepoURL: "",
+		},
+		{
+			name: "no repo",
+			args: args{
+				chart: "base",
+			},
+			wantName:    "base",
+			wantRepoURL: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotRepoURL := chartReference(tt.args.repo, tt.args.chart)
+			if gotName != tt.wantName {
+				t.Errorf("chartReference() name = %v, want %v", gotName, tt.wantName)
+			}
+			if gotRepoURL != tt.wantRepoURL {
+				t.Errorf("chartReference() repoURL = %v, want %v", gotRepoURL, tt.wantRepoURL)
+			}
+		})
+	}
+}
+
+func Test_resolveRepo(t *testing.T) {
+	appRepo := &operatorv1alpha1.HelmRepo{Name: "app", Url: "https://charts.example.com"}
+	componentRepo := &operatorv1alpha1.HelmRepo{Name: "component", Url: "oci://registry.example.com/charts"}
+
+	tests := []struct {
+		name      string
+		component *operatorv1alpha1.HelmComponent
+		want      *operatorv1alpha1.HelmRepo
+	}{
+		{
+			name:      "inherit app repo",
+			component: &operatorv1alpha1.HelmComponent{Name: "base"},
+			want:      appRepo,
+		},
+		{
+			name:      "component repo without url",
+			component: &operatorv1alpha1.HelmComponent{Name: "base", Repo: &operatorv1alpha1.HelmRepo{Name: "empty"}},
+			want:      appRepo,
+		},
+		{
+			name:      "override with component repo",
+			component: &operatorv1alpha1.HelmComponent{Name: "base", Repo: componentRepo},
+			want:      componentRepo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helmApp := &operatorv1alpha1.HelmApp{Spec: &operatorv1alpha1.HelmAppSpec{Repo: appRepo}}
+			if got := resolveRepo(helmApp, tt.component); got != tt.want {
+				t.Errorf("resolveRepo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unchangedRelease(t *testing.T) {
+	values := map[string]any{"pilot": map[string]any{"replicaCount": float64(2)}}
+	helmCfg := &helmaction.Configuration{Releases: storage.Init(driver.NewMemory())}
+	err := helmCfg.Releases.Create(&helmrelease.Release{
+		Name:    "istiod",
+		Version: 1,
+		Info:    &helmrelease.Info{Status: helmrelease.StatusDeployed},
+		Chart:   &helmchart.Chart{Metadata: &helmchart.Metadata{Name: "istiod", Version: "1.21.1"}},
+		Config:  values,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		generation int64
+		version    string
+		values     map[string]any
+		want       bool
+	}{
+		{name: "unchanged", generation: 2, version: "1.21.1", values: values, want: true},
+		{name: "new generation", generation: 3, version: "1.21.1", values: values},
+		{name: "new version", generation: 2, version: "1.22.0", values: values},
+		{name: "new values", generation: 2, version: "1.21.1", values: map[string]any{"pilot": map[string]any{"replicaCount": float64(3)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helmApp := &operatorv1alpha1.HelmApp{Status: &operatorv1alpha1.HelmAppStatus{ObservedGeneration: 2}}
+			helmApp.Generation = tt.generation
+			component := &operatorv1alpha1.HelmComponent{Name: "istiod", Version: tt.version}
+			previous := &operatorv1alpha1.HelmComponentStatus{AppliedValuesHash: valuesHash(values)}
+			if got := unchangedRelease(helmApp, component, previous, tt.values, helmCfg) != nil; got != tt.want {
+				t.Errorf("unchangedRelease() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
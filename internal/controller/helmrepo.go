@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	helmaction "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/registry"
+	corev1 "k8s.io/api/core/v1"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys read from the secrets referenced by a HelmRepo
+const (
+	repoUsernameKey = "username"
+	repoPasswordKey = "password"
+	repoTokenKey    = "token"
+	repoCAKey       = "ca.crt"
+)
+
+// repoCredentials holds the credentials and TLS settings of a HelmRepo resolved from its secrets.
+// Helm only accepts certificates as files, so they are written to a private temporary directory
+// which is removed by cleanup.
+type repoCredentials struct {
+	username string
+	password string
+	token    string
+
+	certFile string
+	keyFile  string
+	caFile   string
+
+	insecureSkipTLSVerify bool
+	passCredentialsAll    bool
+
+	// digest is the SHA-256 of the credentials read from the secret, empty without credentials
+	digest string
+
+	dir string
+}
+
+// loadRepoCredentials resolves the secrets referenced by repo from the HelmApp namespace.
+// Errors never contain secret data, so they are safe to put into the HelmApp status.
+func (r *HelmAppReconciler) loadRepoCredentials(ctx context.Context, namespace string, repo *operatorv1alpha1.HelmRepo) (creds *repoCredentials, err error) {
+	creds = &repoCredentials{
+		insecureSkipTLSVerify: repo.GetInsecureSkipTLSVerify(),
+		passCredentialsAll:    repo.GetPassCredentialsAll(),
+	}
+	defer func() {
+		if err != nil {
+			creds.cleanup()
+		}
+	}()
+
+	if name := repo.GetSecretRef().GetName(); name != "" {
+		secret := &corev1.Secret{}
+		if err = r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get repo secret %s/%s: %w", namespace, name, err)
+		}
+		creds.username = string(secret.Data[repoUsernameKey])
+		creds.password = string(secret.Data[repoPasswordKey])
+		creds.token = string(secret.Data[repoTokenKey])
+		creds.digest = credentialsDigest(secret.Data, repoUsernameKey, repoPasswordKey, repoTokenKey,
+			corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		if creds.certFile, err = creds.writeFile(corev1.TLSCertKey, secret.Data[corev1.TLSCertKey]); err != nil {
+			return nil, err
+		}
+		if creds.keyFile, err = creds.writeFile(corev1.TLSPrivateKeyKey, secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+			return nil, err
+		}
+		if creds.caFile, err = creds.writeFile(repoCAKey, secret.Data[repoCAKey]); err != nil {
+			return nil, err
+		}
+	}
+
+	if name := repo.GetCaSecretRef().GetName(); name != "" {
+		secret := &corev1.Secret{}
+		if err = r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get repo CA secret %s/%s: %w", namespace, name, err)
+		}
+		if len(secret.Data[repoCAKey]) == 0 {
+			return nil, fmt.Errorf("repo CA secret %s/%s has no %s key", namespace, name, repoCAKey)
+		}
+		if creds.caFile, err = creds.writeFile(repoCAKey, secret.Data[repoCAKey]); err != nil {
+			return nil, err
+		}
+	}
+
+	return creds, nil
+}
+
+// credentialsDigest returns the SHA-256 of the given keys of the data of a secret.
+func credentialsDigest(data map[string][]byte, keys ...string) string {
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%d:", key, len(data[key]))
+		hash.Write(data[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *repoCredentials) writeFile(name string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	dir, err := c.tempDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write repo %s: %w", name, err)
+	}
+	return path, nil
+}
+
+func (c *repoCredentials) tempDir() (string, error) {
+	if c.dir == "" {
+		dir, err := os.MkdirTemp("", "helmrepo-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create repo credentials dir: %w", err)
+		}
+		c.dir = dir
+	}
+	return c.dir, nil
+}
+
+func (c *repoCredentials) cleanup() {
+	if c == nil || c.dir == "" {
+		return
+	}
+	if err := os.RemoveAll(c.dir); err != nil {
+		warning("failed to remove repo credentials dir: %v", err)
+	}
+}
+
+func (c *repoCredentials) hasAuth() bool {
+	return c.username != "" || c.password != "" || c.token != ""
+}
+
+func (c *repoCredentials) hasTLS() bool {
+	return c.certFile != "" || c.keyFile != "" || c.caFile != "" || c.insecureSkipTLSVerify
+}
+
+// apply sets the credentials on the options used to locate a chart from an http repo.
+func (c *repoCredentials) apply(opts *helmaction.ChartPathOptions) {
+	opts.Username = c.username
+	opts.Password = c.password
+	opts.CertFile = c.certFile
+	opts.KeyFile = c.keyFile
+	opts.CaFile = c.caFile
+	opts.InsecureSkipTLSverify = c.insecureSkipTLSVerify
+	opts.PassCredentialsAll = c.passCredentialsAll
+}
+
+// registryClient returns a registry client logged into the registry of ref, or nil when the
+// repo has neither credentials nor TLS settings and the shared client can be used.
+// The client keeps its logins in the temporary directory of the credentials, so they are
+// never written to the credentials file shared by all HelmApps.
+func (c *repoCredentials) registryClient(ref string) (*registry.Client, error) {
+	if !c.hasAuth() && !c.hasTLS() {
+		return nil, nil
+	}
+	dir, err := c.tempDir()
+	if err != nil {
+		return nil, err
+	}
+	credentialsFile := filepath.Join(dir, "config.json")
+
+	var registryClient *registry.Client
+	if c.hasTLS() {
+		registryClient, err = registry.NewRegistryClientWithTLS(io.Discard, c.certFile, c.keyFile, c.caFile,
+			c.insecureSkipTLSVerify, credentialsFile, false)
+	} else {
+		registryClient, err = registry.NewClient(
+			registry.ClientOptEnableCache(true),
+			registry.ClientOptWriter(io.Discard),
+			registry.ClientOptCredentialsFile(credentialsFile),
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("initializing helm registry client: %w", err)
+	}
+
+	if c.hasAuth() {
+		// an empty username makes the password be used as a bearer token
+		username, password := c.username, c.password
+		if c.token != "" {
+			username, password = "", c.token
+		}
+		host := registryHost(ref)
+		if err := registryClient.Login(host,
+			registry.LoginOptBasicAuth(username, password),
+			registry.LoginOptInsecure(c.insecureSkipTLSVerify),
+			registry.LoginOptTLSClientConfig(c.certFile, c.keyFile, c.caFile),
+		); err != nil {
+			return nil, fmt.Errorf("failed to login to registry %s: %w", host, err)
+		}
+	}
+	return registryClient, nil
+}
+
+// registryHost returns the host of an oci:// chart reference.
+func registryHost(ref string) string {
+	host := strings.TrimPrefix(ref, fmt.Sprintf("%s://", registry.OCIScheme))
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
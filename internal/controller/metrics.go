@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Helm operations recorded in the metrics
+const (
+	operationInstall   = "install"
+	operationUpgrade   = "upgrade"
+	operationUninstall = "uninstall"
+	operationRollback  = "rollback"
+	operationTest      = "test"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var (
+	operationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pluma_helmapp_operations_total",
+		Help: "Number of helm operations on the components of a HelmApp by outcome.",
+	}, []string{"namespace", "helmapp", "component", "operation", "result"})
+
+	operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pluma_helmapp_operation_duration_seconds",
+		Help:    "Duration of the helm operations on the components of a HelmApp.",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"namespace", "helmapp", "component", "operation"})
+
+	chartDownloadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pluma_helmapp_chart_download_duration_seconds",
+		Help:    "Time to locate and download the chart of a component.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"namespace", "helmapp", "component"})
+
+	chartCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pluma_helmapp_chart_cache_requests_total",
+		Help: "Number of charts looked up in the chart cache by result.",
+	}, []string{"result"})
+
+	componentStatusGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pluma_helmapp_component_status",
+		Help: "Status of the release of a component, 1 for the current status.",
+	}, []string{"namespace", "helmapp", "component", "status"})
+
+	componentReadyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pluma_helmapp_component_ready",
+		Help: "Whether the Ready condition of a component is true.",
+	}, []string{"namespace", "helmapp", "component"})
+
+	driftedResourcesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pluma_helmapp_drifted_resources",
+		Help: "Number of resources of a component which drifted from the release manifest.",
+	}, []string{"namespace", "helmapp", "component"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(operationsTotal, operationDuration, chartDownloadDuration, chartCacheRequests,
+		componentStatusGauge, componentReadyGauge, driftedResourcesGauge)
+}
+
+// observeOperation records the outcome and duration of a helm operation started at start.
+func observeOperation(helmApp *operatorv1alpha1.HelmApp, component, operation string, start time.Time, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+	operationsTotal.WithLabelValues(helmApp.Namespace, helmApp.Name, component, operation, result).Inc()
+	operationDuration.WithLabelValues(helmApp.Namespace, helmApp.Name, component, operation).Observe(time.Since(start).Seconds())
+}
+
+// observeChartDownload records the time spent locating the chart of a component since start.
+func observeChartDownload(helmApp *operatorv1alpha1.HelmApp, component string, start time.Time) {
+	chartDownloadDuration.WithLabelValues(helmApp.Namespace, helmApp.Name, component).Observe(time.Since(start).Seconds())
+}
+
+// observeChartCache counts a lookup of the chart cache.
+func observeChartCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	chartCacheRequests.WithLabelValues(result).Inc()
+}
+
+// recordComponentMetrics sets the gauges of a component from its status.
+func recordComponentMetrics(helmApp *operatorv1alpha1.HelmApp, status *operatorv1alpha1.HelmComponentStatus) {
+	componentStatusGauge.DeletePartialMatch(componentLabels(helmApp, status.GetName()))
+	componentStatusGauge.WithLabelValues(helmApp.Namespace, helmApp.Name, status.GetName(), status.GetStatus()).Set(1)
+
+	ready := 0.0
+	if c := findCondition(status.GetConditions(), conditionReady); c != nil && c.Status == conditionTrue {
+		ready = 1
+	}
+	componentReadyGauge.WithLabelValues(helmApp.Namespace, helmApp.Name, status.GetName()).Set(ready)
+	driftedResourcesGauge.WithLabelValues(helmApp.Namespace, helmApp.Name, status.GetName()).Set(float64(status.GetDriftedResources()))
+}
+
+// deleteComponentMetrics drops the series of an uninstalled component, or of every component of
+// the HelmApp if component is empty.
+func deleteComponentMetrics(helmApp *operatorv1alpha1.HelmApp, component string) {
+	labels := componentLabels(helmApp, component)
+	for _, vec := range []interface {
+		DeletePartialMatch(prometheus.Labels) int
+	}{operationsTotal, operationDuration, chartDownloadDuration, componentStatusGauge, componentReadyGauge, driftedResourcesGauge} {
+		vec.DeletePartialMatch(labels)
+	}
+}
+
+func componentLabels(helmApp *operatorv1alpha1.HelmApp, component string) prometheus.Labels {
+	labels := prometheus.Labels{"namespace": helmApp.Namespace, "helmapp": helmApp.Name}
+	if component != "" {
+		labels["component"] = component
+	}
+	return labels
+}
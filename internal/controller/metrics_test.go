@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func Test_recordComponentMetrics(t *testing.T) {
+	helmApp := &operatorv1alpha1.HelmApp{}
+	helmApp.Namespace, helmApp.Name = "istio-system", "metrics-test"
+
+	recordComponentMetrics(helmApp, &operatorv1alpha1.HelmComponentStatus{Name: "istiod", Status: "pending-upgrade"})
+	recordComponentMetrics(helmApp, &operatorv1alpha1.HelmComponentStatus{
+		Name:             "istiod",
+		Status:           "deployed",
+		DriftedResources: 2,
+		Conditions:       []*operatorv1alpha1.Condition{{Type: conditionReady, Status: conditionTrue}},
+	})
+
+	if got := gaugeValue(t, componentStatusGauge.WithLabelValues("istio-system", "metrics-test", "istiod", "deployed")); got != 1 {
+		t.Errorf("component status = %v, want 1", got)
+	}
+	if got := gaugeValue(t, componentReadyGauge.WithLabelValues("istio-system", "metrics-test", "istiod")); got != 1 {
+		t.Errorf("component ready = %v, want 1", got)
+	}
+	if got := gaugeValue(t, driftedResourcesGauge.WithLabelValues("istio-system", "metrics-test", "istiod")); got != 2 {
+		t.Errorf("drifted resources = %v, want 2", got)
+	}
+	// The previous status is replaced rather than kept at 1
+	labels := componentLabels(helmApp, "istiod")
+	if got := componentStatusGauge.DeletePartialMatch(labels); got != 1 {
+		t.Errorf("component status series = %v, want 1", got)
+	}
+
+	deleteComponentMetrics(helmApp, "")
+	if got := driftedResourcesGauge.DeletePartialMatch(labels); got != 0 {
+		t.Errorf("drifted resources series after delete = %v, want 0", got)
+	}
+}
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	m := &dto.Metric{}
+	if err := gauge.Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetGauge().GetValue()
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	helmaction "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ensureNamespace creates the target namespace of a component with the given labels and
+// annotations, or adds them to the namespace if it already exists. Keys set by others are kept.
+func ensureNamespace(ctx context.Context, kubeClient client.Client, name string, metadata *operatorv1alpha1.NamespaceMetadata) error {
+	ns := &corev1.Namespace{}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Name: name}, ns); err != nil {
+		if !errors2.IsNotFound(err) {
+			return fmt.Errorf("failed to get namespace %s: %w", name, err)
+		}
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      metadata.GetLabels(),
+				Annotations: metadata.GetAnnotations(),
+			},
+		}
+		if err := kubeClient.Create(ctx, ns); err != nil && !errors2.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %w", name, err)
+		}
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	changed := false
+	ns.Labels, changed = mergeStringMap(ns.Labels, metadata.GetLabels(), changed)
+	ns.Annotations, changed = mergeStringMap(ns.Annotations, metadata.GetAnnotations(), changed)
+	if !changed {
+		return nil
+	}
+	if err := kubeClient.Patch(ctx, ns, patch); err != nil {
+		return fmt.Errorf("failed to update namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// mergeStringMap sets the entries of from in to, reporting whether anything changed.
+func mergeStringMap(to, from map[string]string, changed bool) (map[string]string, bool) {
+	for k, v := range from {
+		if current, ok := to[k]; ok && current == v {
+			continue
+		}
+		if to == nil {
+			to = make(map[string]string, len(from))
+		}
+		to[k] = v
+		changed = true
+	}
+	return to, changed
+}
+
+// moveRelease uninstalls the release of a component from the namespace it moved away from, before
+// it is installed in the new one: helm only lets a release adopt resources annotated with its own
+// namespace, so the cluster-scoped resources of the chart would conflict while the previous
+// release owns them. The deletion policy doesn't apply, the release moves rather than goes away,
+// and the resources helm keeps on uninstall are handed over to the release in the new namespace.
+func (r *HelmAppReconciler) moveRelease(ctx context.Context, helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
+	from, to string, configs *actionConfigs) error {
+	helmCfg, err := configs.get(from)
+	if err != nil {
+		return err
+	}
+	release, err := helmCfg.Releases.Last(component.Name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get release in namespace %s: %w", from, err)
+	}
+
+	// Hand the kept resources over first, they are out of reach once the release is gone
+	if err := adoptKeptResources(ctx, configs.client, release.Manifest, from, to); err != nil {
+		r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonUninstallFailed, "%v", err)
+		return err
+	}
+
+	start := time.Now()
+	_, err = helmaction.NewUninstall(helmCfg).Run(component.Name)
+	observeOperation(helmApp, component.Name, operationUninstall, start, err)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		err = fmt.Errorf("failed to uninstall release from namespace %s: %w", from, err)
+		r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonUninstallFailed, "%v", err)
+		return err
+	}
+	r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonUninstalled,
+		"uninstalled release from namespace %s, moving it to %s", from, to)
+	return nil
+}
+
+// adoptKeptResources sets the release namespace annotation of the resources of the manifest helm
+// keeps on uninstall to the namespace the release moves to, so the release installed there adopts
+// them. Resources which no longer exist, or whose kind doesn't, are skipped.
+func adoptKeptResources(ctx context.Context, kubeClient client.Client, manifest, from, to string) error {
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &obj.Object); err != nil || obj.GetKind() == "" || !hasKeepPolicy(obj.GetAnnotations()) {
+			continue
+		}
+		namespaced, err := kubeClient.IsObjectNamespaced(obj)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get the scope of %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		namespace := ""
+		if namespaced {
+			namespace = obj.GetNamespace()
+			if namespace == "" {
+				namespace = from
+			}
+		}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: obj.GetName()}, live); err != nil {
+			if errors2.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		annotations := live.GetAnnotations()
+		if annotations["meta.helm.sh/release-namespace"] == to {
+			continue
+		}
+		patch := client.MergeFrom(live.DeepCopy())
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations["meta.helm.sh/release-namespace"] = to
+		live.SetAnnotations(annotations)
+		if err := kubeClient.Patch(ctx, live, patch); err != nil {
+			return fmt.Errorf("failed to hand %s/%s over to namespace %s: %w", obj.GetKind(), obj.GetName(), to, err)
+		}
+	}
+	return nil
+}
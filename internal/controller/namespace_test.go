@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	helmaction "helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_ensureNamespace(t *testing.T) {
+	metadata := &operatorv1alpha1.NamespaceMetadata{
+		Labels:      map[string]string{"istio-injection": "enabled"},
+		Annotations: map[string]string{"owner": "mesh"},
+	}
+	tests := []struct {
+		name            string
+		existing        []client.Object
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "create",
+			wantLabels:      map[string]string{"istio-injection": "enabled"},
+			wantAnnotations: map[string]string{"owner": "mesh"},
+		},
+		{
+			name: "merge into existing",
+			existing: []client.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "istio-ingress",
+				Labels: map[string]string{"team": "network", "istio-injection": "disabled"},
+			}}},
+			wantLabels:      map[string]string{"team": "network", "istio-injection": "enabled"},
+			wantAnnotations: map[string]string{"owner": "mesh"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &HelmAppReconciler{Client: fake.NewClientBuilder().WithObjects(tt.existing...).Build()}
+			if err := ensureNamespace(context.Background(), r.Client, "istio-ingress", metadata); err != nil {
+				t.Fatalf("ensureNamespace() error = %v", err)
+			}
+			ns := &corev1.Namespace{}
+			if err := r.Get(context.Background(), client.ObjectKey{Name: "istio-ingress"}, ns); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantLabels, ns.Labels); diff != "" {
+				t.Errorf("labels mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantAnnotations, ns.Annotations); diff != "" {
+				t.Errorf("annotations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_releaseNamespace(t *testing.T) {
+	helmApp := &operatorv1alpha1.HelmApp{}
+	helmApp.Namespace = "istio-system"
+	if got := releaseNamespace(helmApp, ""); got != "istio-system" {
+		t.Errorf("releaseNamespace() = %s, want istio-system", got)
+	}
+	if got := releaseNamespace(helmApp, "istio-ingress"); got != "istio-ingress" {
+		t.Errorf("releaseNamespace() = %s, want istio-ingress", got)
+	}
+}
+
+const istiodManifest = `---
+# Source: istiod/templates/clusterrole.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istiod
+---
+# Source: istiod/templates/reader-clusterrole.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istio-reader
+  annotations:
+    helm.sh/resource-policy: keep
+`
+
+func Test_moveRelease(t *testing.T) {
+	owned := func(name string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				"meta.helm.sh/release-name":      "istiod",
+				"meta.helm.sh/release-namespace": "istio-system",
+			},
+		}}
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), meta.RESTScopeRoot)
+	kubeClient := fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(owned("istiod"), owned("istio-reader")).Build()
+	r := &HelmAppReconciler{Client: kubeClient}
+	kube := &kubefake.FailingKubeClient{
+		PrintingKubeClient:         kubefake.PrintingKubeClient{Out: io.Discard},
+		BuildDummy:                 true,
+		DeleteWithPropagationError: errors.New("connection refused"),
+	}
+	configs := newActionConfigs(kubeClient, nil)
+	discovery := &fakediscovery.FakeDiscovery{
+		Fake:               &clienttesting.Fake{Resources: []*metav1.APIResourceList{{GroupVersion: "v1"}}},
+		FakedServerVersion: &version.Info{GitVersion: "v1.31.0", Major: "1", Minor: "31"},
+	}
+	configs.configs["istio-system"] = &helmaction.Configuration{
+		RESTClientGetter: &restConfigGetter{config: &rest.Config{}, discovery: memory.NewMemCacheClient(discovery)},
+		Releases:         storage.Init(driver.NewMemory()),
+		KubeClient:       kube,
+		Log:              func(string, ...any) {},
+	}
+	release := &helmrelease.Release{
+		Name:      "istiod",
+		Namespace: "istio-system",
+		Version:   1,
+		Info:      &helmrelease.Info{Status: helmrelease.StatusDeployed},
+		Manifest:  istiodManifest,
+	}
+	if err := configs.configs["istio-system"].Releases.Create(release); err != nil {
+		t.Fatal(err)
+	}
+	helmApp := &operatorv1alpha1.HelmApp{}
+	helmApp.Name = "istio"
+	component := &operatorv1alpha1.HelmComponent{Name: "istiod"}
+
+	// The uninstall fails, the release stays in the previous namespace to be retried
+	if err := r.moveRelease(context.Background(), helmApp, component, "istio-system", "istio-control", configs); err == nil {
+		t.Fatal("moveRelease() expected an error")
+	}
+	if _, err := configs.configs["istio-system"].Releases.Last("istiod"); err != nil {
+		t.Fatalf("release was dropped after a failed uninstall: %v", err)
+	}
+
+	kube.DeleteWithPropagationError = nil
+	if err := r.moveRelease(context.Background(), helmApp, component, "istio-system", "istio-control", configs); err != nil {
+		t.Fatalf("moveRelease() error = %v", err)
+	}
+	if _, err := configs.configs["istio-system"].Releases.Last("istiod"); !errors.Is(err, driver.ErrReleaseNotFound) {
+		t.Errorf("release is still in the previous namespace: %v", err)
+	}
+	// Nothing to move once the release is gone
+	if err := r.moveRelease(context.Background(), helmApp, component, "istio-system", "istio-control", configs); err != nil {
+		t.Fatalf("moveRelease() error = %v", err)
+	}
+
+	want := map[string]string{"istiod": "istio-system", "istio-reader": "istio-control"}
+	for name, namespace := range want {
+		role := &rbacv1.ClusterRole{}
+		if err := kubeClient.Get(context.Background(), client.ObjectKey{Name: name}, role); err != nil {
+			t.Fatal(err)
+		}
+		if got := role.Annotations["meta.helm.sh/release-namespace"]; got != namespace {
+			t.Errorf("ClusterRole %s release namespace = %s, want %s", name, got, namespace)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	helmaction "helm.sh/helm/v3/pkg/action"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+// planApprovalAnnotation holds the hash of the approved plan of a HelmApp in plan mode
+const planApprovalAnnotation = "helmapp.pluma.io/approve-plan"
+
+// maxStatusDiffSize is the size of the diffs above which they are stored in a ConfigMap
+const maxStatusDiffSize = 16 << 10
+
+// Planned release actions
+const (
+	planActionInstall = "install"
+	planActionUpgrade = "upgrade"
+)
+
+// Planned resource changes
+const (
+	changeCreate = "create"
+	changeUpdate = "update"
+	changeDelete = "delete"
+)
+
+// Values of the Secret keys in the plan diffs, which only tell whether a key changed
+const (
+	redactedValue        = "(redacted)"
+	redactedChangedValue = "(redacted, changed)"
+)
+
+// secretDataFields are the fields of a Secret holding its data
+var secretDataFields = []string{"data", "stringData"}
+
+// planComponent renders the pending install or upgrade of a component with a dry-run and diffs it
+// against the manifest of the current release. It returns nil if nothing would change.
+func planComponent(helmCfg *helmaction.Configuration, namespace string, component *operatorv1alpha1.HelmComponent,
+	previous *operatorv1alpha1.HelmComponentStatus, chart *helmchart.Chart, values map[string]any, history []*helmrelease.Release, historyErr error) (*operatorv1alpha1.HelmComponentPlanStatus, error) {
+	plan := &operatorv1alpha1.HelmComponentPlanStatus{}
+	var current string
+	var desired *helmrelease.Release
+	var err error
+	postRenderer := newPostRenderer(component.GetPatches(), namespace)
+	switch {
+	case errors.Is(historyErr, driver.ErrReleaseNotFound):
+		install := helmaction.NewInstall(helmCfg)
+		install.Namespace = namespace
+		install.ReleaseName = component.Name
+		install.DryRun = true
+		// Existing resources are adopted or reported by the real install
+		install.IsUpgrade = true
+		install.PostRenderer = postRenderer
+		plan.Action = planActionInstall
+		desired, err = install.Run(chart, values)
+	case historyErr == nil && len(history) > 0 && (hasConfigChanged(history[0], values, component.Version) ||
+		patchesHash(component.GetPatches()) != previous.GetAppliedPatchesHash()):
+		upgrade := helmaction.NewUpgrade(helmCfg)
+		upgrade.Namespace = namespace
+		upgrade.DryRun = true
+		upgrade.PostRenderer = postRenderer
+		plan.Action = planActionUpgrade
+		plan.Revision = int32(history[0].Version)
+		current = history[0].Manifest
+		desired, err = upgrade.Run(component.Name, chart, values)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the %s plan: %w", plan.Action, err)
+	}
+
+	plan.Changes, plan.Diff = manifestChanges(current, desired.Manifest)
+	if len(plan.Changes) == 0 {
+		return nil, nil
+	}
+	// The diff redacts the values of Secrets, the hash covers them so an approval holds for the values
+	// it was given for only
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%d\n%s\n%s", plan.Action, plan.Revision, plan.Diff, secretsDigest(desired.Manifest))))
+	plan.Hash = hex.EncodeToString(sum[:])
+	return plan, nil
+}
+
+// secretsDigest returns the digest of the data of the Secrets of a manifest.
+func secretsDigest(manifest string) string {
+	resources := manifestResources(manifest)
+	keys := make([]string, 0, len(resources))
+	for key, resource := range resources {
+		if resource.secretData != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		for _, field := range secretDataFields {
+			data := resources[key].secretData[field]
+			names := make([]string, 0, len(data))
+			for name := range data {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Fprintf(hash, "%s\n%s\n%s\n%q\n", key, field, name, data[name])
+			}
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// planMove adds the resources of the release in the namespace the component moved from to its plan,
+// they are deleted once the release is installed in the new namespace. The hash covers the move, so
+// it is applied only once approved.
+func planMove(plan *operatorv1alpha1.HelmComponentPlanStatus, name, previousNamespace string, configs *actionConfigs) error {
+	helmCfg, err := configs.get(previousNamespace)
+	if err != nil {
+		return err
+	}
+	release, err := helmCfg.Releases.Last(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get release in namespace %s: %w", previousNamespace, err)
+	}
+	moveChanges(plan, previousNamespace, release)
+	return nil
+}
+
+// moveChanges adds the resources of the release uninstalled from previousNamespace to the plan.
+func moveChanges(plan *operatorv1alpha1.HelmComponentPlanStatus, previousNamespace string, release *helmrelease.Release) {
+	resources := manifestResources(release.Manifest)
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		change := resources[key].change
+		change.Action = changeDelete
+		plan.Changes = append(plan.Changes, change)
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\nmove from %s revision %d", plan.Hash, previousNamespace, release.Version)))
+	plan.Hash = hex.EncodeToString(sum[:])
+}
+
+// manifestChanges compares two release manifests resource by resource. It returns the changed
+// resources and the unified diff of their manifests.
+func manifestChanges(current, desired string) ([]*operatorv1alpha1.HelmResourceChange, string) {
+	currentResources := manifestResources(current)
+	desiredResources := manifestResources(desired)
+	keys := make([]string, 0, len(currentResources)+len(desiredResources))
+	for key := range currentResources {
+		keys = append(keys, key)
+	}
+	for key := range desiredResources {
+		if _, ok := currentResources[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []*operatorv1alpha1.HelmResourceChange
+	var diff strings.Builder
+	for _, key := range keys {
+		before, after := currentResources[key], desiredResources[key]
+		beforeManifest, afterManifest := before.redactedManifest(nil), after.redactedManifest(before.secretData)
+		if beforeManifest == afterManifest {
+			continue
+		}
+		var change *operatorv1alpha1.HelmResourceChange
+		switch {
+		case before.change == nil:
+			change = after.change
+			change.Action = changeCreate
+		case after.change == nil:
+			change = before.change
+			change.Action = changeDelete
+		default:
+			change = after.change
+			change.Action = changeUpdate
+		}
+		changes = append(changes, change)
+
+		text, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(beforeManifest),
+			B:        difflib.SplitLines(afterManifest),
+			FromFile: "current/" + key,
+			ToFile:   "desired/" + key,
+			Context:  3,
+		})
+		diff.WriteString(text)
+	}
+	return changes, diff.String()
+}
+
+type manifestResource struct {
+	change   *operatorv1alpha1.HelmResourceChange
+	manifest string
+	// keep tells whether helm keeps the resource on uninstall
+	keep bool
+	// secretData holds the data and stringData of a Secret by field, they are left out of its
+	// manifest so plans never hold secret values
+	secretData map[string]map[string]string
+}
+
+// redactedManifest returns the manifest of the resource with the keys of a Secret, their values
+// redacted. Keys whose value differs from the one in current are marked as changed.
+func (r manifestResource) redactedManifest(current map[string]map[string]string) string {
+	if r.secretData == nil {
+		return r.manifest
+	}
+	obj := map[string]any{}
+	if err := yaml.Unmarshal([]byte(r.manifest), &obj); err != nil {
+		return r.manifest
+	}
+	for field, data := range r.secretData {
+		redacted := make(map[string]any, len(data))
+		for key, value := range data {
+			redacted[key] = redactedValue
+			if old, ok := current[field][key]; ok && old != value {
+				redacted[key] = redactedChangedValue
+			}
+		}
+		obj[field] = redacted
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return r.manifest
+	}
+	return string(out)
+}
+
+// splitSecretData removes the data and stringData of a Secret document, it returns the manifest
+// without them and the removed values by field.
+func splitSecretData(doc string) (string, map[string]map[string]string) {
+	obj := map[string]any{}
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		// Never keep what can't be parsed, it may hold secret values
+		return "", map[string]map[string]string{}
+	}
+	secretData := map[string]map[string]string{}
+	for _, field := range secretDataFields {
+		value, ok := obj[field]
+		if !ok {
+			continue
+		}
+		delete(obj, field)
+		data, _ := value.(map[string]any)
+		values := make(map[string]string, len(data))
+		for key, value := range data {
+			values[key] = fmt.Sprint(value)
+		}
+		secretData[field] = values
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", secretData
+	}
+	return string(out), secretData
+}
+
+// manifestResources indexes the resources of a manifest by kind, namespace and name.
+func manifestResources(manifest string) map[string]manifestResource {
+	resources := make(map[string]manifestResource)
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		var head struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name        string            `json:"name"`
+				Namespace   string            `json:"namespace"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		change := &operatorv1alpha1.HelmResourceChange{
+			Kind:      head.Kind,
+			Namespace: head.Metadata.Namespace,
+			Name:      head.Metadata.Name,
+		}
+		key := strings.Join([]string{change.Kind, change.Namespace, change.Name}, "/")
+		resource := manifestResource{
+			change:   change,
+			manifest: strings.TrimSpace(doc) + "\n",
+			keep:     hasKeepPolicy(head.Metadata.Annotations),
+		}
+		if head.Kind == "Secret" {
+			resource.manifest, resource.secretData = splitSecretData(doc)
+		}
+		resources[key] = resource
+	}
+	return resources
+}
+
+// planApproved tells whether the plan of the component is the one approved with the annotation.
+func planApproved(helmApp *operatorv1alpha1.HelmApp, previous *operatorv1alpha1.HelmComponentStatus,
+	plan *operatorv1alpha1.HelmComponentPlanStatus) bool {
+	approved := helmApp.Annotations[planApprovalAnnotation]
+	return approved != "" && approved == helmApp.Status.GetPlan().GetHash() && previous.GetPlan().GetHash() == plan.GetHash()
+}
+
+// planConfigMapName returns the name of the ConfigMap holding the large diffs of a HelmApp.
+func planConfigMapName(helmApp *operatorv1alpha1.HelmApp) string {
+	return helmApp.Name + "-plan"
+}
+
+// syncPlan sets the plan of the HelmApp from the plans of its components. The hash covers every
+// component plan, so approving it applies exactly what was reviewed. Diffs too large for the
+// status are moved to a ConfigMap owned by the HelmApp.
+func (r *HelmAppReconciler) syncPlan(ctx context.Context, helmApp *operatorv1alpha1.HelmApp,
+	components []*operatorv1alpha1.HelmComponentStatus) error {
+	var hashes []string
+	diffs := map[string]string{}
+	size := 0
+	for _, status := range components {
+		if plan := status.GetPlan(); plan != nil {
+			hashes = append(hashes, status.GetName()+"="+plan.GetHash())
+			diffs[status.GetName()+".diff"] = plan.GetDiff()
+			size += len(plan.GetDiff())
+		}
+	}
+	hadConfigMap := helmApp.Status.GetPlan().GetDiffConfigMap() != ""
+	if len(hashes) == 0 {
+		helmApp.Status.Plan = nil
+		return r.deletePlanConfigMap(ctx, helmApp, hadConfigMap)
+	}
+
+	sort.Strings(hashes)
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "\n")))
+	helmApp.Status.Plan = &operatorv1alpha1.HelmAppPlanStatus{Hash: hex.EncodeToString(sum[:])}
+	if size <= maxStatusDiffSize {
+		return r.deletePlanConfigMap(ctx, helmApp, hadConfigMap)
+	}
+
+	cm := &corev1.ConfigMap{}
+	cm.Namespace, cm.Name = helmApp.Namespace, planConfigMapName(helmApp)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = diffs
+		return controllerutil.SetControllerReference(helmApp, cm, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to write plan ConfigMap %s: %w", cm.Name, err)
+	}
+	for _, status := range components {
+		if plan := status.GetPlan(); plan != nil {
+			plan.Diff = ""
+		}
+	}
+	helmApp.Status.Plan.DiffConfigMap = cm.Name
+	return nil
+}
+
+// deletePlanConfigMap deletes the diffs ConfigMap of the HelmApp if the last plan had one.
+func (r *HelmAppReconciler) deletePlanConfigMap(ctx context.Context, helmApp *operatorv1alpha1.HelmApp, exists bool) error {
+	if !exists {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	cm.Namespace, cm.Name = helmApp.Namespace, planConfigMapName(helmApp)
+	if err := r.Delete(ctx, cm); err != nil && !errors2.IsNotFound(err) {
+		return fmt.Errorf("failed to delete plan ConfigMap %s: %w", cm.Name, err)
+	}
+	return nil
+}
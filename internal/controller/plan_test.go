@@ -0,0 +1,358 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	helmaction "helm.sh/helm/v3/pkg/action"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_manifestChanges(t *testing.T) {
+	current := `---
+# Source: istiod/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  mesh: "accessLogFile: /dev/stdout"
+---
+# Source: istiod/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: istiod
+  namespace: istio-system
+`
+	desired := `---
+# Source: istiod/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+  namespace: istio-system
+data:
+  mesh: "accessLogFile: \"\""
+---
+# Source: istiod/templates/serviceaccount.yaml
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istiod
+  namespace: istio-system
+`
+	changes, diff := manifestChanges(current, desired)
+	want := []*operatorv1alpha1.HelmResourceChange{
+		{Action: changeUpdate, Kind: "ConfigMap", Namespace: "istio-system", Name: "istio"},
+		{Action: changeDelete, Kind: "Service", Namespace: "istio-system", Name: "istiod"},
+		{Action: changeCreate, Kind: "ServiceAccount", Namespace: "istio-system", Name: "istiod"},
+	}
+	if d := cmp.Diff(want, changes, protocmp.Transform()); d != "" {
+		t.Errorf("manifestChanges() changes mismatch (-want +got):\n%s", d)
+	}
+	for _, line := range []string{
+		"--- current/ConfigMap/istio-system/istio",
+		`-  mesh: "accessLogFile: /dev/stdout"`,
+		`+  mesh: "accessLogFile: \"\""`,
+		"+++ desired/ServiceAccount/istio-system/istiod",
+	} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("manifestChanges() diff is missing %q:\n%s", line, diff)
+		}
+	}
+
+	if changes, diff := manifestChanges(current, current); len(changes) != 0 || diff != "" {
+		t.Errorf("manifestChanges() of the same manifest = %v, %q, want no changes", changes, diff)
+	}
+}
+
+func Test_planComponent(t *testing.T) {
+	helmCfg := &helmaction.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...any) {},
+	}
+	chart := &helmchart.Chart{
+		Metadata: &helmchart.Metadata{APIVersion: "v2", Name: "istiod", Version: "1.21.1"},
+		Templates: []*helmchart.File{{Name: "templates/configmap.yaml", Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio
+data:
+  logLevel: {{ .Values.logLevel }}
+`)}},
+	}
+	component := &operatorv1alpha1.HelmComponent{Name: "istiod", Version: "1.21.1"}
+
+	// Nothing is installed yet
+	plan, err := planComponent(helmCfg, "istio-system", component, nil, chart, map[string]any{"logLevel": "info"}, nil, driver.ErrReleaseNotFound)
+	if err != nil {
+		t.Fatalf("planComponent() error = %v", err)
+	}
+	if plan.GetAction() != planActionInstall || len(plan.GetChanges()) != 1 || plan.GetChanges()[0].GetAction() != changeCreate {
+		t.Errorf("planComponent() = %v, want an install creating the ConfigMap", plan)
+	}
+	if releases, _ := helmCfg.Releases.ListReleases(); len(releases) != 0 {
+		t.Errorf("planComponent() stored %d releases, want none", len(releases))
+	}
+
+	// Upgrade the deployed release with new values
+	install := helmaction.NewInstall(helmCfg)
+	install.Namespace, install.ReleaseName = "istio-system", "istiod"
+	deployed, err := install.Run(chart, map[string]any{"logLevel": "info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := []*helmrelease.Release{deployed}
+	plan, err = planComponent(helmCfg, "istio-system", component, nil, chart, map[string]any{"logLevel": "debug"}, history, nil)
+	if err != nil {
+		t.Fatalf("planComponent() error = %v", err)
+	}
+	if plan.GetAction() != planActionUpgrade || plan.GetRevision() != 1 || len(plan.GetChanges()) != 1 ||
+		plan.GetChanges()[0].GetAction() != changeUpdate || !strings.Contains(plan.GetDiff(), "+  logLevel: debug\n") {
+		t.Errorf("planComponent() = %v, want an upgrade of revision 1 updating the ConfigMap", plan)
+	}
+
+	// Same values, nothing to plan
+	plan, err = planComponent(helmCfg, "istio-system", component, nil, chart, map[string]any{"logLevel": "info"}, history, nil)
+	if err != nil || plan != nil {
+		t.Errorf("planComponent() = %v, %v, want no plan", plan, err)
+	}
+}
+
+func Test_planComponent_secrets(t *testing.T) {
+	helmCfg := &helmaction.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...any) {},
+	}
+	chart := &helmchart.Chart{
+		Metadata: &helmchart.Metadata{APIVersion: "v2", Name: "istiod", Version: "1.21.1"},
+		Templates: []*helmchart.File{{Name: "templates/secret.yaml", Data: []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: istio-ca
+stringData:
+  password: {{ .Values.password }}
+`)}},
+	}
+	component := &operatorv1alpha1.HelmComponent{Name: "istiod", Version: "1.21.1"}
+	install := helmaction.NewInstall(helmCfg)
+	install.Namespace, install.ReleaseName = "istio-system", "istiod"
+	deployed, err := install.Run(chart, map[string]any{"password": "hunter1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := []*helmrelease.Release{deployed}
+
+	// The diffs of two rotations look the same, their hashes don't
+	plans := make([]*operatorv1alpha1.HelmComponentPlanStatus, 0, 2)
+	for _, password := range []string{"hunter2", "hunter3"} {
+		plan, err := planComponent(helmCfg, "istio-system", component, nil, chart, map[string]any{"password": password}, history, nil)
+		if err != nil {
+			t.Fatalf("planComponent() error = %v", err)
+		}
+		if strings.Contains(plan.GetDiff(), "hunter") {
+			t.Errorf("planComponent() diff holds the password:\n%s", plan.GetDiff())
+		}
+		plans = append(plans, plan)
+	}
+	if plans[0].GetDiff() != plans[1].GetDiff() {
+		t.Errorf("planComponent() diffs differ:\n%s\n%s", plans[0].GetDiff(), plans[1].GetDiff())
+	}
+	if plans[0].GetHash() == plans[1].GetHash() {
+		t.Errorf("planComponent() hashes of different passwords are both %s", plans[0].GetHash())
+	}
+}
+
+func Test_manifestChanges_secrets(t *testing.T) {
+	current := `---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: istio-ca
+  namespace: istio-system
+data:
+  ca.crt: Y2VydA==
+  ca.key: a2V5
+stringData:
+  password: hunter2
+`
+	desired := `---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: istio-ca
+  namespace: istio-system
+data:
+  ca.crt: Y2VydA==
+  ca.key: bmV3LWtleQ==
+  cert-chain.pem: Y2hhaW4=
+stringData:
+  password: hunter2
+`
+	changes, diff := manifestChanges(current, desired)
+	want := []*operatorv1alpha1.HelmResourceChange{
+		{Action: changeUpdate, Kind: "Secret", Namespace: "istio-system", Name: "istio-ca"},
+	}
+	if d := cmp.Diff(want, changes, protocmp.Transform()); d != "" {
+		t.Errorf("manifestChanges() changes mismatch (-want +got):\n%s", d)
+	}
+	for _, line := range []string{
+		"-  ca.key: (redacted)",
+		"+  ca.key: (redacted, changed)",
+		"+  cert-chain.pem: (redacted)",
+		"   ca.crt: (redacted)",
+	} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("manifestChanges() diff is missing %q:\n%s", line, diff)
+		}
+	}
+	for _, value := range []string{"Y2VydA==", "a2V5", "bmV3LWtleQ==", "Y2hhaW4=", "hunter2"} {
+		if strings.Contains(diff, value) {
+			t.Errorf("manifestChanges() diff holds the secret value %q:\n%s", value, diff)
+		}
+	}
+
+	// A change of a secret value alone is still a change
+	rotated := strings.Replace(current, "hunter2", "hunter3", 1)
+	if changes, diff := manifestChanges(current, rotated); len(changes) != 1 || strings.Contains(diff, "hunter") {
+		t.Errorf("manifestChanges() of a rotated password = %v, %q, want a redacted update", changes, diff)
+	}
+}
+
+func Test_moveChanges(t *testing.T) {
+	release := &helmrelease.Release{Name: "gateway", Version: 3, Manifest: `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: gateway
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: gateway
+`}
+	plan := &operatorv1alpha1.HelmComponentPlanStatus{
+		Hash:    "install",
+		Action:  planActionInstall,
+		Changes: []*operatorv1alpha1.HelmResourceChange{{Action: changeCreate, Kind: "Service", Name: "gateway"}},
+	}
+	moveChanges(plan, "istio-system", release)
+
+	want := []*operatorv1alpha1.HelmResourceChange{
+		{Action: changeCreate, Kind: "Service", Name: "gateway"},
+		{Action: changeDelete, Kind: "Deployment", Name: "gateway"},
+		{Action: changeDelete, Kind: "Service", Name: "gateway"},
+	}
+	if diff := cmp.Diff(want, plan.Changes, protocmp.Transform()); diff != "" {
+		t.Errorf("moveChanges() changes mismatch (-want +got):\n%s", diff)
+	}
+
+	// Approving the install doesn't approve the move
+	other := &operatorv1alpha1.HelmComponentPlanStatus{Hash: "install"}
+	moveChanges(other, "istio-ingress", release)
+	if plan.Hash == "install" || plan.Hash == other.Hash {
+		t.Errorf("moveChanges() hash = %q, want a hash of the move", plan.Hash)
+	}
+}
+
+func Test_planApproved(t *testing.T) {
+	plan := &operatorv1alpha1.HelmComponentPlanStatus{Hash: "c1"}
+	newHelmApp := func(annotation, appHash, componentHash string) *operatorv1alpha1.HelmApp {
+		helmApp := &operatorv1alpha1.HelmApp{Status: &operatorv1alpha1.HelmAppStatus{
+			Plan: &operatorv1alpha1.HelmAppPlanStatus{Hash: appHash},
+			Components: []*operatorv1alpha1.HelmComponentStatus{
+				{Name: "istiod", Plan: &operatorv1alpha1.HelmComponentPlanStatus{Hash: componentHash}},
+			},
+		}}
+		if annotation != "" {
+			helmApp.Annotations = map[string]string{planApprovalAnnotation: annotation}
+		}
+		return helmApp
+	}
+	tests := []struct {
+		name    string
+		helmApp *operatorv1alpha1.HelmApp
+		want    bool
+	}{
+		{name: "approved", helmApp: newHelmApp("a1", "a1", "c1"), want: true},
+		{name: "not approved", helmApp: newHelmApp("", "a1", "c1")},
+		{name: "older plan approved", helmApp: newHelmApp("a0", "a1", "c1")},
+		{name: "plan changed since approval", helmApp: newHelmApp("a1", "a1", "c0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := planApproved(tt.helmApp, previousStatus(tt.helmApp, "istiod"), plan); got != tt.want {
+				t.Errorf("planApproved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncPlan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := operatorv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	r := &HelmAppReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	helmApp := &operatorv1alpha1.HelmApp{Status: &operatorv1alpha1.HelmAppStatus{}}
+	helmApp.Namespace, helmApp.Name = "istio-system", "mesh"
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "istio-system", Name: "mesh-plan"}
+
+	// Large diffs are moved to the ConfigMap
+	large := strings.Repeat("+  a: b\n", maxStatusDiffSize)
+	components := []*operatorv1alpha1.HelmComponentStatus{
+		{Name: "base"},
+		{Name: "istiod", Plan: &operatorv1alpha1.HelmComponentPlanStatus{Hash: "c1", Diff: large}},
+	}
+	if err := r.syncPlan(ctx, helmApp, components); err != nil {
+		t.Fatalf("syncPlan() error = %v", err)
+	}
+	if helmApp.Status.GetPlan().GetHash() == "" || helmApp.Status.GetPlan().GetDiffConfigMap() != "mesh-plan" {
+		t.Errorf("syncPlan() plan = %v, want a hash and the ConfigMap", helmApp.Status.GetPlan())
+	}
+	if components[1].GetPlan().GetDiff() != "" {
+		t.Errorf("syncPlan() kept the large diff in the status")
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, key, cm); err != nil {
+		t.Fatalf("plan ConfigMap: %v", err)
+	}
+	if cm.Data["istiod.diff"] != large {
+		t.Errorf("plan ConfigMap doesn't hold the diff of istiod")
+	}
+
+	// Once applied, the plan and the ConfigMap are removed
+	if err := r.syncPlan(ctx, helmApp, []*operatorv1alpha1.HelmComponentStatus{{Name: "istiod"}}); err != nil {
+		t.Fatalf("syncPlan() error = %v", err)
+	}
+	if helmApp.Status.GetPlan() != nil {
+		t.Errorf("syncPlan() plan = %v, want nil", helmApp.Status.GetPlan())
+	}
+	if err := r.Get(ctx, key, cm); err == nil {
+		t.Errorf("plan ConfigMap still exists")
+	}
+}
@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"helm.sh/helm/v3/pkg/postrender"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// Types of the post-render patches
+const (
+	patchTypeStrategicMerge = "StrategicMerge"
+	patchTypeJSON6902       = "JSON6902"
+)
+
+// patchPostRenderer applies the patches of a component to its rendered manifests.
+type patchPostRenderer struct {
+	patches []*operatorv1alpha1.PostRenderPatch
+	// namespace is the namespace of the resources which don't set one
+	namespace string
+}
+
+var _ postrender.PostRenderer = &patchPostRenderer{}
+
+// newPostRenderer returns the post-renderer of the patches, or nil if there is none.
+func newPostRenderer(patches []*operatorv1alpha1.PostRenderPatch, namespace string) postrender.PostRenderer {
+	if len(patches) == 0 {
+		return nil
+	}
+	return &patchPostRenderer{patches: patches, namespace: namespace}
+}
+
+// patchesHash returns the SHA-256 of the patches, or an empty string if there is none.
+func patchesHash(patches []*operatorv1alpha1.PostRenderPatch) string {
+	if len(patches) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(patches)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Run patches the resources of the rendered manifests. Every patch has to match at least one
+// resource, so a stale target doesn't go unnoticed.
+func (p *patchPostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	manifests := releaseutil.SplitManifests(renderedManifests.String())
+	keys := make([]string, 0, len(manifests))
+	for key := range manifests {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	docs := make([]*renderedDoc, 0, len(keys))
+	for _, key := range keys {
+		doc, err := parseRenderedDoc(manifests[key])
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	for i, patch := range p.patches {
+		matched := 0
+		for _, doc := range docs {
+			if doc.obj == nil {
+				continue
+			}
+			ok, err := p.matches(patch.GetTarget(), doc.obj)
+			if err != nil {
+				return nil, fmt.Errorf("patch %d: %w", i, err)
+			}
+			if !ok {
+				continue
+			}
+			if err := applyPatch(doc, patch); err != nil {
+				return nil, fmt.Errorf("patch %d of %s/%s: %w", i, doc.obj.GetKind(), doc.obj.GetName(), err)
+			}
+			matched++
+		}
+		if matched == 0 {
+			return nil, fmt.Errorf("patch %d matches no resource", i)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	for _, doc := range docs {
+		out.WriteString("---\n")
+		out.WriteString(doc.text)
+		out.WriteString("\n")
+	}
+	return out, nil
+}
+
+// matches tells whether the resource is selected by the target of a patch.
+func (p *patchPostRenderer) matches(target *operatorv1alpha1.PatchTarget, obj *unstructured.Unstructured) (bool, error) {
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = p.namespace
+	}
+	switch {
+	case target.GetKind() != "" && target.GetKind() != obj.GetKind():
+		return false, nil
+	case target.GetName() != "" && target.GetName() != obj.GetName():
+		return false, nil
+	case target.GetNamespace() != "" && target.GetNamespace() != namespace:
+		return false, nil
+	case target.GetLabelSelector() == "":
+		return true, nil
+	}
+	selector, err := labels.Parse(target.GetLabelSelector())
+	if err != nil {
+		return false, fmt.Errorf("invalid label selector: %w", err)
+	}
+	return selector.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// renderedDoc is a resource of the rendered manifests.
+type renderedDoc struct {
+	// header holds the comments before the resource, like the template it comes from
+	header string
+	text   string
+	// obj is nil for documents without a resource
+	obj *unstructured.Unstructured
+}
+
+func parseRenderedDoc(text string) (*renderedDoc, error) {
+	doc := &renderedDoc{text: strings.TrimSpace(text)}
+	var header []string
+	for _, line := range strings.Split(doc.text, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		header = append(header, line)
+	}
+	doc.header = strings.Join(header, "\n")
+
+	obj := map[string]any{}
+	if err := yaml.Unmarshal([]byte(text), &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered manifest: %w", err)
+	}
+	if len(obj) > 0 {
+		doc.obj = &unstructured.Unstructured{Object: obj}
+	}
+	return doc, nil
+}
+
+// applyPatch patches the resource of the document and renders it again.
+func applyPatch(doc *renderedDoc, patch *operatorv1alpha1.PostRenderPatch) error {
+	original, err := json.Marshal(doc.obj.Object)
+	if err != nil {
+		return err
+	}
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch.GetPatch()))
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+
+	var patched []byte
+	switch patch.GetType() {
+	case "", patchTypeStrategicMerge:
+		patched, err = strategicMergePatch(doc.obj, original, patchJSON)
+	case patchTypeJSON6902:
+		var ops jsonpatch.Patch
+		if ops, err = jsonpatch.DecodePatch(patchJSON); err != nil {
+			return fmt.Errorf("invalid patch: %w", err)
+		}
+		patched, err = ops.Apply(original)
+	default:
+		return fmt.Errorf("unknown patch type %q", patch.GetType())
+	}
+	if err != nil {
+		return err
+	}
+
+	obj := map[string]any{}
+	if err := json.Unmarshal(patched, &obj); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	doc.obj = &unstructured.Unstructured{Object: obj}
+	doc.text = strings.TrimSpace(string(data))
+	if doc.header != "" {
+		doc.text = doc.header + "\n" + doc.text
+	}
+	return nil
+}
+
+// strategicMergePatch applies a strategic merge patch to the resource. Kinds without a known
+// schema, like custom resources, get a JSON merge patch.
+func strategicMergePatch(obj *unstructured.Unstructured, original, patch []byte) ([]byte, error) {
+	schema, err := scheme.Scheme.New(obj.GroupVersionKind())
+	if runtime.IsNotRegisteredError(err) {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strategicpatch.StrategicMergePatch(original, patch, schema)
+}
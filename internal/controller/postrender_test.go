@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+const renderedManifests = `---
+# Source: istiod/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  labels:
+    app: istiod
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: pilot:1.21.1
+      - name: sidecar
+        image: sidecar:1.0
+---
+# Source: istiod/templates/gateway.yaml
+apiVersion: networking.istio.io/v1
+kind: Gateway
+metadata:
+  name: ingress
+  namespace: istio-ingress
+spec:
+  selector:
+    istio: ingressgateway
+`
+
+func Test_patchPostRenderer(t *testing.T) {
+	tests := []struct {
+		name    string
+		patches []*operatorv1alpha1.PostRenderPatch
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "strategic merge keeps the other containers",
+			patches: []*operatorv1alpha1.PostRenderPatch{{
+				Target: &operatorv1alpha1.PatchTarget{Kind: "Deployment", Name: "istiod"},
+				Patch: `spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: pilot:1.21.2`,
+			}},
+			want: []string{"# Source: istiod/templates/deployment.yaml\n", "image: pilot:1.21.2", "image: sidecar:1.0"},
+		},
+		{
+			name: "json merge patch of a custom resource",
+			patches: []*operatorv1alpha1.PostRenderPatch{{
+				Target: &operatorv1alpha1.PatchTarget{Kind: "Gateway", Namespace: "istio-ingress"},
+				Patch:  `{"spec": {"selector": {"istio": "ingress"}}}`,
+			}},
+			want: []string{"istio: ingress\n"},
+		},
+		{
+			name: "json6902 selected by labels in the release namespace",
+			patches: []*operatorv1alpha1.PostRenderPatch{{
+				Target: &operatorv1alpha1.PatchTarget{Namespace: "istio-system", LabelSelector: "app=istiod"},
+				Type:   patchTypeJSON6902,
+				Patch: `- op: replace
+  path: /spec/template/spec/containers/1/image
+  value: sidecar:2.0`,
+			}},
+			want: []string{"image: pilot:1.21.1", "image: sidecar:2.0"},
+		},
+		{
+			name: "no resource matched",
+			patches: []*operatorv1alpha1.PostRenderPatch{{
+				Target: &operatorv1alpha1.PatchTarget{Kind: "Service"},
+				Patch:  `metadata: {labels: {a: b}}`,
+			}},
+			wantErr: "patch 0 matches no resource",
+		},
+		{
+			name: "failed operation",
+			patches: []*operatorv1alpha1.PostRenderPatch{{
+				Target: &operatorv1alpha1.PatchTarget{Kind: "Gateway"},
+				Type:   patchTypeJSON6902,
+				Patch:  `[{"op": "remove", "path": "/spec/servers"}]`,
+			}},
+			wantErr: "patch 0 of Gateway/ingress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := newPostRenderer(tt.patches, "istio-system")
+			out, err := renderer.Run(bytes.NewBufferString(renderedManifests))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("Run() output is missing %q:\n%s", s, out)
+				}
+			}
+			if got := len(manifestResources(out.String())); got != 2 {
+				t.Errorf("Run() output has %d resources, want 2", got)
+			}
+		})
+	}
+}
+
+func Test_patchesHash(t *testing.T) {
+	patches := []*operatorv1alpha1.PostRenderPatch{{Patch: "metadata: {labels: {a: b}}"}}
+	if got := patchesHash(nil); got != "" {
+		t.Errorf("patchesHash(nil) = %q, want empty", got)
+	}
+	changed := []*operatorv1alpha1.PostRenderPatch{{Patch: "metadata: {labels: {a: c}}"}}
+	if patchesHash(patches) == patchesHash(changed) {
+		t.Errorf("patchesHash() is the same for different patches")
+	}
+	if configHash("istiod", "1.21.1", nil, "") == configHash("istiod", "1.21.1", nil, patchesHash(patches)) {
+		t.Errorf("configHash() ignores the patches")
+	}
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	helmaction "helm.sh/helm/v3/pkg/action"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	ctllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Results of the helm tests of a release
+const (
+	testResultSucceeded = "Succeeded"
+	testResultFailed    = "Failed"
+)
+
+// Log excerpt of a test pod kept in the status
+const (
+	testLogLines = 20
+	testLogBytes = 2 << 10
+)
+
+// defaultTestTimeout is the time the tests of a release have when the policy doesn't set one
+const defaultTestTimeout = 5 * time.Minute
+
+func testTimeout(policy *operatorv1alpha1.TestPolicy) time.Duration {
+	if d := policy.GetTimeout(); d != nil && d.AsDuration() > 0 {
+		return d.AsDuration()
+	}
+	return defaultTestTimeout
+}
+
+// testRelease runs the tests of a release which was just installed or upgraded and records the
+// results in the component status. It returns the release left deployed, which is the previous
+// revision when failed tests rolled the upgrade back.
+func (r *HelmAppReconciler) testRelease(ctx context.Context, helmApp *operatorv1alpha1.HelmApp, component *operatorv1alpha1.HelmComponent,
+	helmCfg *helmaction.Configuration, release *helmrelease.Release, componentStatus, previous *operatorv1alpha1.HelmComponentStatus,
+	hash string, mErrs *multierror.Error) *helmrelease.Release {
+	cLog := ctllog.FromContext(ctx)
+	policy := component.GetTest()
+
+	testing := helmaction.NewReleaseTesting(helmCfg)
+	testing.Namespace = release.Namespace
+	testing.Timeout = testTimeout(policy)
+	start := time.Now()
+	tested, err := testing.Run(release.Name)
+	if tested == nil {
+		tested = release
+	}
+	componentStatus.LastTest = testResults(tested, err, func(pod string) string {
+		return testPodLog(ctx, helmCfg, release.Namespace, pod)
+	})
+	if componentStatus.LastTest.Result == testResultSucceeded {
+		observeOperation(helmApp, component.Name, operationTest, start, nil)
+		r.componentEvent(helmApp, component, corev1.EventTypeNormal, eventReasonTestSucceeded,
+			"%d tests of revision %d succeeded", len(componentStatus.LastTest.Tests), release.Version)
+		return release
+	}
+
+	testErr := fmt.Errorf("tests of revision %d failed", release.Version)
+	if err != nil {
+		testErr = fmt.Errorf("tests of revision %d failed: %v", release.Version, err)
+	}
+	observeOperation(helmApp, component.Name, operationTest, start, testErr)
+	cLog.Error(testErr, "Release tests failed", "component", component.Name)
+	multierror.Append(mErrs, testErr)
+	recordAttempt(componentStatus, previous, hash, true)
+	r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonTestFailed, "%v", testErr)
+
+	// A fresh install has no revision to go back to
+	if !policy.GetRollbackOnFailure() || release.Version <= 1 {
+		return release
+	}
+	start = time.Now()
+	rollback, err := rollbackRelease(helmCfg, release, component.GetUpgradePolicy(), testErr.Error())
+	observeOperation(helmApp, component.Name, operationRollback, start, err)
+	if err != nil {
+		multierror.Append(mErrs, err)
+		r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonRollbackFailed, "%v", err)
+		return release
+	}
+	componentStatus.LastRollback = rollback
+	r.componentEvent(helmApp, component, corev1.EventTypeWarning, eventReasonRolledBack,
+		"rolled back revision %d to revision %d", rollback.FromRevision, rollback.ToRevision)
+	if deployed, err := helmCfg.Releases.Last(release.Name); err == nil {
+		return deployed
+	}
+	return release
+}
+
+// testResults reads the outcome of every test hook of the release. podLog returns the log excerpt
+// of a test pod.
+func testResults(release *helmrelease.Release, runErr error, podLog func(string) string) *operatorv1alpha1.HelmTestStatus {
+	status := &operatorv1alpha1.HelmTestStatus{
+		Revision: int32(release.Version),
+		Result:   testResultSucceeded,
+		Time:     timestamppb.Now(),
+	}
+	if runErr != nil {
+		status.Result = testResultFailed
+	}
+	for _, hook := range release.Hooks {
+		if !isTestHook(hook) {
+			continue
+		}
+		phase := helmrelease.HookPhaseUnknown
+		if hook.LastRun.Phase != "" {
+			phase = hook.LastRun.Phase
+		}
+		result := &operatorv1alpha1.HelmTestResult{Name: hook.Name, Phase: phase.String()}
+		if phase == helmrelease.HookPhaseFailed {
+			status.Result = testResultFailed
+		}
+		if hook.Kind == "Pod" && phase != helmrelease.HookPhaseUnknown {
+			result.Log = podLog(hook.Name)
+		}
+		status.Tests = append(status.Tests, result)
+	}
+	return status
+}
+
+func isTestHook(hook *helmrelease.Hook) bool {
+	for _, event := range hook.Events {
+		if event == helmrelease.HookTest {
+			return true
+		}
+	}
+	return false
+}
+
+// testPodLog returns the last lines of the logs of a test pod, or why they couldn't be read.
+func testPodLog(ctx context.Context, helmCfg *helmaction.Configuration, namespace, pod string) string {
+	clientSet, err := helmCfg.KubernetesClientSet()
+	if err != nil {
+		return fmt.Sprintf("failed to read logs: %v", err)
+	}
+	logs, err := clientSet.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		TailLines:  ptr.To[int64](testLogLines),
+		LimitBytes: ptr.To[int64](testLogBytes),
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Sprintf("failed to read logs: %v", err)
+	}
+	defer logs.Close()
+	data, err := io.ReadAll(logs)
+	if err != nil {
+		return fmt.Sprintf("failed to read logs: %v", err)
+	}
+	return strings.TrimSpace(string(data))
+}
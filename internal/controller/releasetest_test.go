@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func Test_testResults(t *testing.T) {
+	testHook := func(name, kind string, phase helmrelease.HookPhase) *helmrelease.Hook {
+		return &helmrelease.Hook{
+			Name:    name,
+			Kind:    kind,
+			Events:  []helmrelease.HookEvent{helmrelease.HookTest},
+			LastRun: helmrelease.HookExecution{Phase: phase},
+		}
+	}
+	podLog := func(pod string) string { return "logs of " + pod }
+	tests := []struct {
+		name   string
+		hooks  []*helmrelease.Hook
+		runErr error
+		want   *operatorv1alpha1.HelmTestStatus
+	}{
+		{
+			name: "succeeded",
+			hooks: []*helmrelease.Hook{
+				{Name: "pre-install", Kind: "Job", Events: []helmrelease.HookEvent{helmrelease.HookPreInstall}},
+				testHook("istiod-test", "Pod", helmrelease.HookPhaseSucceeded),
+			},
+			want: &operatorv1alpha1.HelmTestStatus{Revision: 2, Result: testResultSucceeded, Tests: []*operatorv1alpha1.HelmTestResult{
+				{Name: "istiod-test", Phase: "Succeeded", Log: "logs of istiod-test"},
+			}},
+		},
+		{
+			name: "failed",
+			hooks: []*helmrelease.Hook{
+				testHook("istiod-test", "Pod", helmrelease.HookPhaseFailed),
+				testHook("istiod-test-config", "ConfigMap", helmrelease.HookPhaseSucceeded),
+				testHook("istiod-test-skipped", "Pod", ""),
+			},
+			runErr: errors.New("pod istiod-test failed"),
+			want: &operatorv1alpha1.HelmTestStatus{Revision: 2, Result: testResultFailed, Tests: []*operatorv1alpha1.HelmTestResult{
+				{Name: "istiod-test", Phase: "Failed", Log: "logs of istiod-test"},
+				{Name: "istiod-test-config", Phase: "Succeeded"},
+				{Name: "istiod-test-skipped", Phase: "Unknown"},
+			}},
+		},
+		{
+			name:   "timed out",
+			runErr: errors.New("timed out waiting for the condition"),
+			want:   &operatorv1alpha1.HelmTestStatus{Revision: 2, Result: testResultFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testResults(&helmrelease.Release{Version: 2, Hooks: tt.hooks}, tt.runErr, podLog)
+			if got.GetTime() == nil {
+				t.Errorf("testResults() didn't set the time")
+			}
+			got.Time = nil
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("testResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
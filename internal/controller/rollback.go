@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	helmaction "helm.sh/helm/v3/pkg/action"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+// defaultUpgradeTimeout is the helm default of the --timeout flag
+const defaultUpgradeTimeout = 5 * time.Minute
+
+// upgradeTimeout returns the time to wait for the resources of a release.
+func upgradeTimeout(policy *operatorv1alpha1.UpgradePolicy) time.Duration {
+	if d := policy.GetTimeout(); d != nil && d.AsDuration() > 0 {
+		return d.AsDuration()
+	}
+	return defaultUpgradeTimeout
+}
+
+// configHash identifies the chart, version, values and post-render patches a release is installed
+// or upgraded with.
+func configHash(chart, version string, values map[string]any, patches string) string {
+	config := map[string]any{"chart": chart, "version": version, "values": values}
+	if patches != "" {
+		config["patches"] = patches
+	}
+	data, _ := json.Marshal(config)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// retriesExhausted tells whether the configuration already failed as many times as the policy allows.
+func retriesExhausted(previous *operatorv1alpha1.HelmComponentStatus, policy *operatorv1alpha1.UpgradePolicy, hash string) bool {
+	maxRetries := policy.GetMaxRetries()
+	return maxRetries > 0 && previous.GetFailedConfigHash() == hash && previous.GetFailures() >= maxRetries
+}
+
+// recordAttempt updates the failure tracking of the component status after installing or upgrading
+// the configuration identified by hash.
+func recordAttempt(status, previous *operatorv1alpha1.HelmComponentStatus, hash string, failed bool) {
+	if !failed {
+		status.Failures = 0
+		status.FailedConfigHash = ""
+		return
+	}
+	status.Failures = 1
+	if previous.GetFailedConfigHash() == hash {
+		status.Failures = previous.GetFailures() + 1
+	}
+	status.FailedConfigHash = hash
+}
+
+// lastDeployedRevision returns the latest revision of the release before the given one which was
+// successfully deployed, or 0 if there is none.
+func lastDeployedRevision(helmCfg *helmaction.Configuration, name string, before int) (int, error) {
+	history, err := helmaction.NewHistory(helmCfg).Run(name)
+	if err != nil {
+		return 0, err
+	}
+	revision := 0
+	for _, rel := range history {
+		if rel.Version >= before || rel.Version <= revision {
+			continue
+		}
+		// Failed revisions are only superseded by a successful upgrade
+		if rel.Info.Status == helmrelease.StatusDeployed || rel.Info.Status == helmrelease.StatusSuperseded {
+			revision = rel.Version
+		}
+	}
+	return revision, nil
+}
+
+// rollbackRelease rolls the failed revision of a release back to the last deployed revision and
+// returns the rollback to record in the component status.
+func rollbackRelease(helmCfg *helmaction.Configuration, failed *helmrelease.Release, policy *operatorv1alpha1.UpgradePolicy,
+	reason string) (*operatorv1alpha1.HelmRollbackStatus, error) {
+	revision, err := lastDeployedRevision(helmCfg, failed.Name, failed.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the last deployed revision: %w", err)
+	}
+	if revision == 0 {
+		return nil, fmt.Errorf("no deployed revision to roll back to")
+	}
+
+	rollback := helmaction.NewRollback(helmCfg)
+	rollback.Version = revision
+	rollback.Wait = true
+	rollback.Timeout = upgradeTimeout(policy)
+	rollback.CleanupOnFail = policy.GetCleanupOnFail()
+	if err := rollback.Run(failed.Name); err != nil {
+		return nil, fmt.Errorf("failed to roll back to revision %d: %w", revision, err)
+	}
+	return newRollbackStatus(failed.Version, revision, reason), nil
+}
+
+func newRollbackStatus(from, to int, reason string) *operatorv1alpha1.HelmRollbackStatus {
+	return &operatorv1alpha1.HelmRollbackStatus{
+		FromRevision: int32(from),
+		ToRevision:   int32(to),
+		Reason:       reason,
+		Time:         timestamppb.Now(),
+	}
+}
+
+// recoverFailedUpgrade applies the rollback policy to a failed upgrade. It returns the rollback
+// performed, if any, and the release left deployed.
+func recoverFailedUpgrade(helmCfg *helmaction.Configuration, failed *helmrelease.Release, policy *operatorv1alpha1.UpgradePolicy,
+	upgradeErr error) (*operatorv1alpha1.HelmRollbackStatus, *helmrelease.Release, error) {
+	if failed == nil {
+		// The upgrade failed before a revision was created, there is nothing to roll back
+		return nil, nil, nil
+	}
+	var rollback *operatorv1alpha1.HelmRollbackStatus
+	switch {
+	case policy.GetAtomic():
+		// Helm already rolled back if the upgrade created a revision
+		revision, err := lastDeployedRevision(helmCfg, failed.Name, failed.Version)
+		if err != nil || revision == 0 {
+			return nil, nil, err
+		}
+		rollback = newRollbackStatus(failed.Version, revision, upgradeErr.Error())
+	case policy.GetRollbackOnFailure():
+		var err error
+		if rollback, err = rollbackRelease(helmCfg, failed, policy, upgradeErr.Error()); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, nil
+	}
+	last, err := helmCfg.Releases.Last(failed.Name)
+	if err != nil || last.Version <= failed.Version {
+		// The atomic rollback didn't happen
+		return nil, nil, err
+	}
+	return rollback, last, nil
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"io"
+	"testing"
+
+	helmaction "helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+)
+
+func Test_lastDeployedRevision(t *testing.T) {
+	helmCfg := &helmaction.Configuration{
+		Releases:   storage.Init(driver.NewMemory()),
+		KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+		Log:        func(string, ...any) {},
+	}
+	for version, status := range []helmrelease.Status{
+		helmrelease.StatusSuperseded,
+		helmrelease.StatusSuperseded,
+		helmrelease.StatusFailed,
+		helmrelease.StatusDeployed,
+		helmrelease.StatusFailed,
+	} {
+		rel := &helmrelease.Release{
+			Name:    "istiod",
+			Version: version + 1,
+			Info:    &helmrelease.Info{Status: status},
+		}
+		if err := helmCfg.Releases.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		before int
+		want   int
+	}{
+		{name: "latest failed", before: 5, want: 4},
+		{name: "skip failed revisions", before: 4, want: 2},
+		{name: "no deployed revision", before: 1, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lastDeployedRevision(helmCfg, "istiod", tt.before)
+			if err != nil {
+				t.Fatalf("lastDeployedRevision() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("lastDeployedRevision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recordAttempt(t *testing.T) {
+	policy := &operatorv1alpha1.UpgradePolicy{MaxRetries: 2}
+	previous := &operatorv1alpha1.HelmComponentStatus{}
+	for i := 0; i < 2; i++ {
+		if retriesExhausted(previous, policy, "a") {
+			t.Fatalf("retriesExhausted() after %d failures = true, want false", i)
+		}
+		status := &operatorv1alpha1.HelmComponentStatus{}
+		recordAttempt(status, previous, "a", true)
+		previous = status
+	}
+	if !retriesExhausted(previous, policy, "a") {
+		t.Errorf("retriesExhausted() after 2 failures = false, want true")
+	}
+	if retriesExhausted(previous, policy, "b") {
+		t.Errorf("retriesExhausted() of a changed configuration = true, want false")
+	}
+
+	status := &operatorv1alpha1.HelmComponentStatus{}
+	recordAttempt(status, previous, "b", true)
+	if status.Failures != 1 {
+		t.Errorf("recordAttempt() of a changed configuration failures = %v, want 1", status.Failures)
+	}
+	recordAttempt(status, previous, "a", false)
+	if status.Failures != 0 || status.FailedConfigHash != "" {
+		t.Errorf("recordAttempt() of a success = %v, %q, want 0, \"\"", status.Failures, status.FailedConfigHash)
+	}
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// templateData is what the string values of a HelmApp are templated with, e.g.
+// {{ .HelmApp.Namespace }} or {{ .Cluster.meshID }}.
+type templateData struct {
+	HelmApp templateHelmApp
+	// Cluster holds the variables of the cluster variables ConfigMap
+	Cluster map[string]string
+}
+
+type templateHelmApp struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// templateData returns the data to template the values of the HelmApp with.
+func (r *HelmAppReconciler) templateData(ctx context.Context, helmApp *operatorv1alpha1.HelmApp) (*templateData, error) {
+	data := &templateData{
+		HelmApp: templateHelmApp{
+			Name:        helmApp.Name,
+			Namespace:   helmApp.Namespace,
+			Labels:      helmApp.Labels,
+			Annotations: helmApp.Annotations,
+		},
+		Cluster: map[string]string{},
+	}
+	name, ok := r.clusterVariablesName()
+	if !ok {
+		return data, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, name, cm); err != nil {
+		return nil, fmt.Errorf("failed to get cluster variables ConfigMap %s: %w", name, err)
+	}
+	for k, v := range cm.Data {
+		data.Cluster[k] = v
+	}
+	return data, nil
+}
+
+// clusterVariablesName returns the name of the cluster variables ConfigMap, if one is configured.
+func (r *HelmAppReconciler) clusterVariablesName() (types.NamespacedName, bool) {
+	namespace, name, ok := strings.Cut(r.Config.ClusterVariablesConfigMap, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// renderValues expands the templates in the string values. Templates only see the data and the
+// builtin functions, a missing key is an error. Without data the values are returned as is, they
+// may hold the templates of a chart.
+func renderValues(values map[string]any, data *templateData) (map[string]any, error) {
+	if data == nil {
+		return values, nil
+	}
+	out, err := renderValue("", values, data)
+	if err != nil {
+		return nil, err
+	}
+	rendered, _ := out.(map[string]any)
+	return rendered, nil
+}
+
+func renderValue(path string, value any, data *templateData) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			itemPath := k
+			if path != "" {
+				itemPath = path + "." + k
+			}
+			rendered, err := renderValue(itemPath, item, data)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = rendered
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			rendered, err := renderValue(fmt.Sprintf("%s[%d]", path, i), item, data)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = rendered
+		}
+		return out, nil
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		tmpl, err := template.New(path).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template of %s: %w", path, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render template of %s: %w", path, err)
+		}
+		return buf.String(), nil
+	}
+	return value, nil
+}
+
+// helmAppsForClusterVariables maps the cluster variables ConfigMap to the HelmApps templating their
+// values.
+func (r *HelmAppReconciler) helmAppsForClusterVariables(ctx context.Context, obj client.Object) []reconcile.Request {
+	name, ok := r.clusterVariablesName()
+	if !ok || obj.GetNamespace() != name.Namespace || obj.GetName() != name.Name {
+		return nil
+	}
+	helmApps := &operatorv1alpha1.HelmAppList{}
+	if err := r.List(ctx, helmApps); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, helmApp := range helmApps.Items {
+		if templatesValues(helmApp) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: helmApp.Namespace, Name: helmApp.Name},
+			})
+		}
+	}
+	return requests
+}
+
+// templatesValues tells whether a component of the HelmApp templates its values.
+func templatesValues(helmApp *operatorv1alpha1.HelmApp) bool {
+	for _, component := range helmApp.Spec.GetComponents() {
+		if component.GetTemplateValues() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_renderValues(t *testing.T) {
+	data := &templateData{
+		HelmApp: templateHelmApp{
+			Name:      "mesh",
+			Namespace: "istio-system",
+			Labels:    map[string]string{"topology.istio.io/network": "network1"},
+		},
+		Cluster: map[string]string{"clusterName": "cluster1", "meshID": "mesh1"},
+	}
+	tests := []struct {
+		name    string
+		values  map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "expand metadata and cluster variables",
+			values: map[string]any{
+				"global": map[string]any{
+					"meshID":       "{{ .Cluster.meshID }}",
+					"network":      `{{ index .HelmApp.Labels "topology.istio.io/network" }}`,
+					"multiCluster": map[string]any{"clusterName": "{{ .Cluster.clusterName }}"},
+				},
+				"pilot": map[string]any{
+					"replicaCount": float64(2),
+					"env":          []any{"{{ .HelmApp.Namespace }}/{{ .HelmApp.Name }}", true},
+				},
+			},
+			want: map[string]any{
+				"global": map[string]any{
+					"meshID":       "mesh1",
+					"network":      "network1",
+					"multiCluster": map[string]any{"clusterName": "cluster1"},
+				},
+				"pilot": map[string]any{
+					"replicaCount": float64(2),
+					"env":          []any{"istio-system/mesh", true},
+				},
+			},
+		},
+		{
+			name:    "missing variable",
+			values:  map[string]any{"global": map[string]any{"network": "{{ .Cluster.network }}"}},
+			wantErr: `failed to render template of global.network: template: global.network:1:11: executing "global.network" at <.Cluster.network>: map has no entry for key "network"`,
+		},
+		{
+			name:    "invalid template",
+			values:  map[string]any{"meshID": "{{ .Cluster.meshID"},
+			wantErr: "failed to parse template of meshID: template: meshID:1: unclosed action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderValues(tt.values, data)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("renderValues() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderValues() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("renderValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_componentValues_templateValues(t *testing.T) {
+	r := &HelmAppReconciler{Client: fake.NewClientBuilder().Build()}
+	helmApp := &operatorv1alpha1.HelmApp{Spec: &operatorv1alpha1.HelmAppSpec{}}
+	helmApp.Name, helmApp.Namespace = "mesh", "istio-system"
+	values, err := structpb.NewStruct(map[string]any{
+		"namespace": "{{ .HelmApp.Namespace }}",
+		"sidecarInjectorWebhook": map[string]any{
+			"templates": map[string]any{"custom": "{{ .Values.global.proxy.image }}"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Values are literal unless the component templates them, chart templates pass through
+	got, err := r.componentValues(context.Background(), helmApp, &operatorv1alpha1.HelmComponent{Name: "istiod", ComponentValues: values})
+	if err != nil {
+		t.Fatalf("componentValues() error = %v", err)
+	}
+	if diff := cmp.Diff(values.AsMap(), got); diff != "" {
+		t.Errorf("componentValues() changed literal values (-want +got):\n%s", diff)
+	}
+
+	_, err = r.componentValues(context.Background(), helmApp,
+		&operatorv1alpha1.HelmComponent{Name: "istiod", ComponentValues: values, TemplateValues: true})
+	if err == nil || !strings.Contains(err.Error(), `can't evaluate field Values`) {
+		t.Errorf("componentValues() with templateValues error = %v, want the chart template rendered", err)
+	}
+}
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"pluma.io/pluma-opeartor/internal/pkg/tools"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+// Kinds of the objects valuesFrom can reference
+const (
+	valuesKindConfigMap = "ConfigMap"
+	valuesKindSecret    = "Secret"
+)
+
+// defaultValuesKey is the key read when a values reference doesn't set one
+const defaultValuesKey = "values.yaml"
+
+// componentValues merges the values of a component, from lowest to highest precedence: the HelmApp
+// valuesFrom, globalValues, the component valuesFrom and componentValues. The HelmApp values are
+// left out if the component ignores global values. Templates in globalValues and componentValues
+// are rendered before merging if the component sets templateValues.
+func (r *HelmAppReconciler) componentValues(ctx context.Context, helmApp *operatorv1alpha1.HelmApp,
+	component *operatorv1alpha1.HelmComponent) (map[string]any, error) {
+	var data *templateData
+	if component.GetTemplateValues() {
+		var err error
+		if data, err = r.templateData(ctx, helmApp); err != nil {
+			return nil, err
+		}
+	}
+
+	values := map[string]any{}
+	if !component.IgnoreGlobalValues {
+		appValues, err := r.valuesFrom(ctx, helmApp.Namespace, helmApp.Spec.GetValuesFrom())
+		if err != nil {
+			return nil, err
+		}
+		globalValues, err := renderValues(helmApp.Spec.GlobalValues.AsMap(), data)
+		if err != nil {
+			return nil, fmt.Errorf("globalValues: %w", err)
+		}
+		values = tools.MergeMaps(appValues, globalValues)
+	}
+	valuesFrom, err := r.valuesFrom(ctx, helmApp.Namespace, component.GetValuesFrom())
+	if err != nil {
+		return nil, err
+	}
+	componentValues, err := renderValues(component.ComponentValues.AsMap(), data)
+	if err != nil {
+		return nil, fmt.Errorf("componentValues: %w", err)
+	}
+	values = tools.MergeMaps(values, valuesFrom)
+	return tools.MergeMaps(values, componentValues), nil
+}
+
+// valuesFrom reads and merges the referenced values in order.
+func (r *HelmAppReconciler) valuesFrom(ctx context.Context, namespace string, refs []*operatorv1alpha1.ValuesReference) (map[string]any, error) {
+	values := map[string]any{}
+	for _, ref := range refs {
+		key := ref.GetValuesKey()
+		if key == "" {
+			key = defaultValuesKey
+		}
+		data, found, err := r.valuesData(ctx, namespace, ref, key)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			if ref.GetOptional() {
+				continue
+			}
+			return nil, fmt.Errorf("key %s not found in %s %s", key, ref.GetKind(), ref.GetName())
+		}
+
+		if ref.GetTargetPath() != "" {
+			// The key holds a single value, set it at the path
+			if err := setValue(values, ref.GetTargetPath(), string(data)); err != nil {
+				return nil, fmt.Errorf("failed to set %s from %s %s: %w", ref.GetTargetPath(), ref.GetKind(), ref.GetName(), err)
+			}
+			continue
+		}
+		refValues := map[string]any{}
+		if err := yaml.Unmarshal(data, &refValues); err != nil {
+			return nil, fmt.Errorf("failed to parse key %s of %s %s: %w", key, ref.GetKind(), ref.GetName(), err)
+		}
+		values = tools.MergeMaps(values, refValues)
+	}
+	return values, nil
+}
+
+// setValue sets the string at the dot-separated path of the values, as is: the data of a key can be
+// a certificate, a JSON document or a password which --set parsing would split or convert. Errors
+// never include the value, it can be secret.
+func setValue(values map[string]any, path, value string) error {
+	fields := strings.Split(path, ".")
+	for _, field := range fields {
+		if field == "" {
+			return fmt.Errorf("invalid path %q", path)
+		}
+	}
+	parent := values
+	for i, field := range fields[:len(fields)-1] {
+		next, ok := parent[field]
+		if !ok {
+			next = map[string]any{}
+			parent[field] = next
+		}
+		m, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s is not a map", strings.Join(fields[:i+1], "."))
+		}
+		parent = m
+	}
+	parent[fields[len(fields)-1]] = value
+	return nil
+}
+
+// valuesData returns the data of the key in the referenced object and whether it was found.
+func (r *HelmAppReconciler) valuesData(ctx context.Context, namespace string, ref *operatorv1alpha1.ValuesReference,
+	key string) ([]byte, bool, error) {
+	name := types.NamespacedName{Namespace: namespace, Name: ref.GetName()}
+	switch ref.GetKind() {
+	case valuesKindConfigMap:
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, name, cm); err != nil {
+			if errors2.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("failed to get ConfigMap %s: %w", ref.GetName(), err)
+		}
+		if data, ok := cm.Data[key]; ok {
+			return []byte(data), true, nil
+		}
+		data, ok := cm.BinaryData[key]
+		return data, ok, nil
+	case valuesKindSecret:
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, name, secret); err != nil {
+			if errors2.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("failed to get Secret %s: %w", ref.GetName(), err)
+		}
+		data, ok := secret.Data[key]
+		return data, ok, nil
+	}
+	return nil, false, fmt.Errorf("unsupported values kind %q", ref.GetKind())
+}
+
+// helmAppsForValues maps a ConfigMap or Secret to the HelmApps reading values from it.
+func (r *HelmAppReconciler) helmAppsForValues(kind string) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		helmApps := &operatorv1alpha1.HelmAppList{}
+		if err := r.List(ctx, helmApps, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, helmApp := range helmApps.Items {
+			if referencesValues(helmApp, kind, obj.GetName()) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: helmApp.Namespace, Name: helmApp.Name},
+				})
+			}
+		}
+		return requests
+	}
+}
+
+// referencesValues tells whether the HelmApp or one of its components reads values from the object.
+func referencesValues(helmApp *operatorv1alpha1.HelmApp, kind, name string) bool {
+	refs := append([]*operatorv1alpha1.ValuesReference{}, helmApp.Spec.GetValuesFrom()...)
+	for _, component := range helmApp.Spec.GetComponents() {
+		refs = append(refs, component.GetValuesFrom()...)
+	}
+	for _, ref := range refs {
+		if ref.GetKind() == kind && ref.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	operatorv1alpha1 "pluma.io/api/operator/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_componentValues(t *testing.T) {
+	r := &HelmAppReconciler{Client: fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "mesh"},
+			Data: map[string]string{"values.yaml": `
+global:
+  hub: docker.io/istio
+  tag: 1.21.1
+meshConfig:
+  accessLogFile: /dev/stdout
+`},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "token"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "raw"},
+			Data: map[string][]byte{
+				"ca.crt":   []byte("-----BEGIN CERTIFICATE-----\nMIIB,a=b[0]\n-----END CERTIFICATE-----\n"),
+				"config":   []byte(`{"mesh": {"id": "mesh1"}, "trust": ["a", "b"]}`),
+				"enabled":  []byte("true"),
+				"password": []byte("p,ss=w{rd"),
+			},
+		},
+	).Build()}
+
+	mustStruct := func(v map[string]any) *structpb.Struct {
+		s, err := structpb.NewStruct(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	helmApp := &operatorv1alpha1.HelmApp{Spec: &operatorv1alpha1.HelmAppSpec{
+		ValuesFrom:   []*operatorv1alpha1.ValuesReference{{Kind: valuesKindConfigMap, Name: "mesh"}},
+		GlobalValues: mustStruct(map[string]any{"global": map[string]any{"tag": "1.21.2"}}),
+	}}
+	helmApp.Namespace = "istio-system"
+
+	tests := []struct {
+		name      string
+		component *operatorv1alpha1.HelmComponent
+		want      map[string]any
+		wantErr   string
+	}{
+		{
+			name: "precedence",
+			component: &operatorv1alpha1.HelmComponent{
+				Name: "istiod",
+				ValuesFrom: []*operatorv1alpha1.ValuesReference{
+					{Kind: valuesKindSecret, Name: "token", ValuesKey: "token", TargetPath: "pilot.env.TOKEN"},
+					{Kind: valuesKindConfigMap, Name: "missing", Optional: true},
+				},
+				ComponentValues: mustStruct(map[string]any{"meshConfig": map[string]any{"accessLogFile": ""}}),
+			},
+			want: map[string]any{
+				"global":     map[string]any{"hub": "docker.io/istio", "tag": "1.21.2"},
+				"meshConfig": map[string]any{"accessLogFile": ""},
+				"pilot":      map[string]any{"env": map[string]any{"TOKEN": "s3cr3t"}},
+			},
+		},
+		{
+			name: "ignore global values",
+			component: &operatorv1alpha1.HelmComponent{
+				Name:               "istiod",
+				IgnoreGlobalValues: true,
+				ValuesFrom: []*operatorv1alpha1.ValuesReference{
+					{Kind: valuesKindSecret, Name: "token", ValuesKey: "token", TargetPath: "pilot.env.TOKEN"},
+				},
+			},
+			want: map[string]any{"pilot": map[string]any{"env": map[string]any{"TOKEN": "s3cr3t"}}},
+		},
+		{
+			name: "raw target path values",
+			component: &operatorv1alpha1.HelmComponent{
+				Name:               "istiod",
+				IgnoreGlobalValues: true,
+				ValuesFrom: []*operatorv1alpha1.ValuesReference{
+					{Kind: valuesKindSecret, Name: "raw", ValuesKey: "ca.crt", TargetPath: "pilot.ca"},
+					{Kind: valuesKindSecret, Name: "raw", ValuesKey: "config", TargetPath: "pilot.config"},
+					{Kind: valuesKindSecret, Name: "raw", ValuesKey: "enabled", TargetPath: "pilot.enabled"},
+					{Kind: valuesKindSecret, Name: "raw", ValuesKey: "password", TargetPath: "pilot.password"},
+				},
+			},
+			want: map[string]any{"pilot": map[string]any{
+				"ca":       "-----BEGIN CERTIFICATE-----\nMIIB,a=b[0]\n-----END CERTIFICATE-----\n",
+				"config":   `{"mesh": {"id": "mesh1"}, "trust": ["a", "b"]}`,
+				"enabled":  "true",
+				"password": "p,ss=w{rd",
+			}},
+		},
+		{
+			name: "target path through a value",
+			component: &operatorv1alpha1.HelmComponent{
+				Name:               "istiod",
+				IgnoreGlobalValues: true,
+				ValuesFrom: []*operatorv1alpha1.ValuesReference{
+					{Kind: valuesKindSecret, Name: "raw", ValuesKey: "password", TargetPath: "pilot.password"},
+					{Kind: valuesKindSecret, Name: "token", ValuesKey: "token", TargetPath: "pilot.password.token"},
+				},
+			},
+			wantErr: "failed to set pilot.password.token from Secret token: pilot.password is not a map",
+		},
+		{
+			name: "missing key",
+			component: &operatorv1alpha1.HelmComponent{
+				Name:       "istiod",
+				ValuesFrom: []*operatorv1alpha1.ValuesReference{{Kind: valuesKindSecret, Name: "token"}},
+			},
+			wantErr: "key values.yaml not found in Secret token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.componentValues(context.Background(), helmApp, tt.component)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("componentValues() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("componentValues() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("componentValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -241,12 +241,22 @@ func (r *IstioOperatorReconciler) convertIopToHelmApp(in *operatorv1alpha1.Istio
 	components := make([]*v1alpha1.HelmComponent, 0)
 	if iop.Spec.GetComponents() != nil {
 		// base
-		if iop.Spec.GetComponents().GetBase().GetEnabled().GetValue() {
+		baseEnabled := iop.Spec.GetComponents().GetBase().GetEnabled().GetValue()
+		if baseEnabled {
 			components = append(components, base)
 		}
 
+		// istiod is installed after base, gateways after istiod
+		istiodEnabled := iop.Spec.GetComponents().GetPilot().GetEnabled().GetValue()
+		if baseEnabled {
+			istiodComponent.DependsOn = []string{base.Name}
+		}
+		if istiodEnabled {
+			ingressGateway.DependsOn = []string{istiodComponent.Name}
+		}
+
 		// istiod
-		if iop.Spec.GetComponents().GetPilot().GetEnabled().GetValue() {
+		if istiodEnabled {
 			// Merge component-specific values
 			componentValues := make(map[string]interface{})
 
@@ -408,6 +418,9 @@ func (r *IstioOperatorReconciler) convertIopToHelmApp(in *operatorv1alpha1.Istio
 			happ.Labels[constants.AllowForceUpgradeLabel] = v
 		}
 	}
+	if v, ok := in.GetAnnotations()[constants.SuspendAnnotation]; ok && v == "true" {
+		happ.Spec.Suspend = true
+	}
 
 	wantYAML, err := yaml.Marshal(happ)
 	if err != nil {
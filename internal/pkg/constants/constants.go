@@ -11,3 +11,6 @@ const (
 	AllowForceUpgradeLabel = "action.pluma.io/allow-froce-upgrade"
 	SourceFromIOP          = "pluma.io/source-from-iop"
 )
+
+// SuspendAnnotation set to "true" on an IstioOperator suspends the HelmApp converted from it
+const SuspendAnnotation = "pluma.io/suspend"